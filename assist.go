@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// nextFeedback cycles the colour of a tile entered in assist mode.
+var nextFeedback = map[Feedback]Feedback{
+	GREY:   YELLOW,
+	YELLOW: GREEN,
+	GREEN:  GREY,
+}
+
+// enterFeedback handles Return in assist mode. The typed word is checked
+// first and its tiles can then be coloured, the second Return enters the
+// guess with that feedback.
+func (m *model) enterFeedback(word string) error {
+	if m.colours == nil {
+		if err := m.game.check(word); err != nil {
+			return err
+		}
+		m.colours = make([]Feedback, m.game.length())
+		for i := range m.colours {
+			m.colours[i] = GREY
+		}
+		m.tile = 0
+		return nil
+	}
+	if err := m.game.enter(word, m.colours); err != nil {
+		return err
+	}
+	m.colours = nil
+	return nil
+}
+
+// handleColours handles the keys while the tiles of a guess are coloured in
+// assist mode.
+func (m *model) handleColours(msg tea.KeyMsg) tea.Cmd {
+	switch key := msg.String(); key {
+	case tea.KeyEnter.String():
+		return m.handleKeyEnter()
+	case tea.KeyEsc.String(), tea.KeyBackspace.String():
+		m.colours = nil
+	case tea.KeyLeft.String():
+		m.tile = (m.tile + len(m.colours) - 1) % len(m.colours)
+	case tea.KeyRight.String(), tea.KeyTab.String():
+		m.tile = (m.tile + 1) % len(m.colours)
+	case tea.KeySpace.String(), tea.KeyUp.String():
+		m.colours[m.tile] = nextFeedback[m.colours[m.tile]]
+	case tea.KeyDown.String():
+		m.colours[m.tile] = nextFeedback[nextFeedback[m.colours[m.tile]]]
+	default:
+		// g, y and b colour the current tile and move on to the next one
+		for value, char := range feedbackChars {
+			if value != TBD && key == string(char) {
+				m.colours[m.tile] = value
+				m.tile = min(m.tile+1, len(m.colours)-1)
+			}
+		}
+		// digits pick a tile and cycle its colour
+		if len(key) == 1 && key[0] >= '1' && int(key[0]-'0') <= len(m.colours) {
+			m.tile = int(key[0] - '1')
+			m.colours[m.tile] = nextFeedback[m.colours[m.tile]]
+		}
+	}
+	return nil
+}
+
+func (m model) ColoursView() string {
+	if m.colours == nil {
+		return ""
+	}
+	var s strings.Builder
+	s.WriteString("Colour the tiles as shown by the puzzle:\n")
+	s.WriteString("  g/y/b or ←/→ and space, digits pick a tile\n")
+	s.WriteString("  Return to enter, Esc to edit the word\n")
+	return helpTextStyle.Copy().MarginBottom(1).Render(s.String())
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func sendKeys(m model, keys ...tea.KeyMsg) model {
+	for _, key := range keys {
+		next, _ := m.update(key)
+		m = next
+	}
+	return m
+}
+
+func runes(s string) []tea.KeyMsg {
+	keys := make([]tea.KeyMsg, 0, len(s))
+	for _, char := range s {
+		keys = append(keys, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}})
+	}
+	return keys
+}
+
+func TestAssistColours(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Mode = ASSIST
+	m := NewTestModel(t, options)
+
+	m = sendKeys(m, runes("adept")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.colours == nil || m.game.attempt != 0 {
+		t.Fatalf("Expected the tiles to be coloured before the guess is entered")
+	}
+
+	// y b y, then cycle the 4th tile twice back to grey and the 5th to yellow
+	m = sendKeys(m, runes("yby")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeySpace}, tea.KeyMsg{Type: tea.KeySpace}, tea.KeyMsg{Type: tea.KeySpace})
+	m = sendKeys(m, runes("5")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.colours != nil || m.game.attempt != 1 {
+		t.Fatalf("Expected the guess to be entered")
+	}
+	if pattern := m.game.boards[0].board[0].pattern(); pattern != "ybyby" {
+		t.Errorf("Expected the pattern 'ybyby' but got '%s'", pattern)
+	}
+
+	m = sendKeys(m, runes("earth")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = sendKeys(m, runes("ggggg")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.colours != nil || m.game.attempt != 1 {
+		t.Errorf("Expected escape to return to the word")
+	}
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = sendKeys(m, runes("ggggg")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.game.status != WIN {
+		t.Errorf("Expected the game to be won with all tiles green")
+	}
+}
+
+func TestAssistRejectsUnknownWords(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Mode = ASSIST
+	m := NewTestModel(t, options)
+	m = sendKeys(m, runes("xxxxx")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.colours != nil {
+		t.Errorf("Expected unknown words not to be coloured")
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const BENCH_BAR_WIDTH = 40
+
+// BenchOptions configure a benchmark run of a solver.
+type BenchOptions struct {
+	Solver  string
+	Game    Options
+	Sample  int // 0 plays every solution
+	Seed    int64
+	Workers int
+	Worst   int // number of hardest words reported
+}
+
+// BenchResult is the outcome of the solver playing a single solution.
+type BenchResult struct {
+	Word    string   `json:"word"`
+	Guesses []string `json:"guesses"`
+	Solved  bool     `json:"solved"`
+}
+
+// BenchReport summarises a benchmark run.
+type BenchReport struct {
+	Solver       string        `json:"solver"`
+	Length       int           `json:"length"`
+	Guesses      int           `json:"guesses"`
+	Hard         bool          `json:"hard"`
+	Seed         int64         `json:"seed"`
+	Games        int           `json:"games"`
+	Wins         int           `json:"wins"`
+	Failures     int           `json:"failures"`
+	Average      float64       `json:"average"` // guesses per win
+	Distribution map[int]int   `json:"distribution"`
+	Worst        []BenchResult `json:"worst"`
+	Failed       []string      `json:"failed"`
+	Seconds      float64       `json:"seconds"`
+}
+
+// benchWords returns the solutions to play, a sample of them is drawn with
+// the seed so runs can be compared.
+func benchWords(options BenchOptions) ([]string, error) {
+	lang, err := language(options.Game.Lang)
+	if err != nil {
+		return nil, err
+	}
+	trie, _, err := loadTries(lang, options.Game.Length)
+	if err != nil {
+		return nil, err
+	}
+	words := trie.words()
+	if options.Sample <= 0 || options.Sample >= len(words) {
+		return words, nil
+	}
+	rng := rand.New(rand.NewSource(options.Seed))
+	sample := make([]string, 0, options.Sample)
+	for _, i := range rng.Perm(len(words))[:options.Sample] {
+		sample = append(sample, words[i])
+	}
+	sort.Strings(sample)
+	return sample, nil
+}
+
+// benchPlay lets solver play the game with the given solution until it is
+// over.
+func benchPlay(solver Solver, word string, options Options) (BenchResult, error) {
+	options.Mode = CHALLENGE
+	options.Solution = word
+	options.Boards = 1
+	game, err := NewGame(options)
+	if err != nil {
+		return BenchResult{}, err
+	}
+	for game.status == ONGOING {
+		ranked := game.rankGuesses(solver, 1)
+		if len(ranked) == 0 {
+			break
+		}
+		if err := game.guess(ranked[0].word); err != nil {
+			return BenchResult{}, err
+		}
+	}
+	return BenchResult{Word: word, Guesses: game.history, Solved: game.status == WIN}, nil
+}
+
+// bench plays every word with its own solver, seeded by the position of the
+// word, so the results only depend on the seed and not on the scheduling.
+func bench(options BenchOptions, words []string) (BenchReport, error) {
+	if options.Workers <= 0 {
+		options.Workers = runtime.NumCPU()
+	}
+	start := time.Now()
+
+	results := make([]BenchResult, len(words))
+	errs := make([]error, options.Workers)
+	var wg sync.WaitGroup
+	for worker := 0; worker < options.Workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := worker; i < len(words); i += options.Workers {
+				solver, err := NewSolver(options.Solver, options.Seed+int64(i))
+				if err == nil {
+					results[i], err = benchPlay(solver, words[i], options.Game)
+				}
+				if err != nil {
+					errs[worker] = fmt.Errorf("Error: Could not play '%s': %w", words[i], err)
+					return
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return BenchReport{}, err
+		}
+	}
+
+	report := BenchReport{
+		Solver:       options.Solver,
+		Length:       options.Game.Length,
+		Guesses:      options.Game.Guesses,
+		Hard:         options.Game.Hard,
+		Seed:         options.Seed,
+		Games:        len(results),
+		Distribution: make(map[int]int),
+		Worst:        make([]BenchResult, 0, options.Worst),
+		Failed:       make([]string, 0),
+	}
+	total := 0
+	for _, result := range results {
+		if !result.Solved {
+			report.Failures++
+			report.Failed = append(report.Failed, result.Word)
+			continue
+		}
+		report.Wins++
+		report.Distribution[len(result.Guesses)]++
+		total += len(result.Guesses)
+	}
+	if report.Wins > 0 {
+		report.Average = float64(total) / float64(report.Wins)
+	}
+
+	// failures first, then the most guesses
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Solved != results[j].Solved {
+			return !results[i].Solved
+		}
+		return len(results[i].Guesses) > len(results[j].Guesses)
+	})
+	for _, result := range results[:min(options.Worst, len(results))] {
+		report.Worst = append(report.Worst, result)
+	}
+	report.Seconds = time.Since(start).Seconds()
+	return report, nil
+}
+
+func (r BenchReport) write(w io.Writer) {
+	fmt.Fprintf(w, "Solver:   %s\n", r.Solver)
+	fmt.Fprintf(w, "Games:    %d (%d letters, %d guesses", r.Games, r.Length, r.Guesses)
+	if r.Hard {
+		fmt.Fprint(w, ", hard")
+	}
+	fmt.Fprintln(w, ")")
+	fmt.Fprintf(w, "Average:  %.4f guesses\n", r.Average)
+	fmt.Fprintf(w, "Failures: %d\n", r.Failures)
+	fmt.Fprintf(w, "Time:     %.2fs\n", r.Seconds)
+
+	fmt.Fprintln(w, "\nDistribution:")
+	max, last := 1, r.Guesses
+	for attempt, count := range r.Distribution {
+		if count > max {
+			max = count
+		}
+		if attempt > last {
+			last = attempt
+		}
+	}
+	for attempt := 1; attempt <= last; attempt++ {
+		count := r.Distribution[attempt]
+		bar := strings.Repeat("#", count*BENCH_BAR_WIDTH/max)
+		fmt.Fprintf(w, "%2d %-*s %d\n", attempt, BENCH_BAR_WIDTH, bar, count)
+	}
+
+	if len(r.Worst) > 0 {
+		fmt.Fprintln(w, "\nWorst:")
+		for _, result := range r.Worst {
+			status := ""
+			if !result.Solved {
+				status = " (failed)"
+			}
+			fmt.Fprintf(w, "  %s: %s%s\n", result.Word, strings.Join(result.Guesses, " "), status)
+		}
+	}
+}
+
+func runBench(args []string) error {
+	options := BenchOptions{Game: DefaultOptions()}
+	flags := flag.NewFlagSet("bench", flag.ContinueOnError)
+	flags.StringVar(&options.Solver, "solver", SOLVERS[0], fmt.Sprintf("solver to benchmark, one of %s", strings.Join(SOLVERS, ", ")))
+	flags.IntVar(&options.Game.Length, "length", DEFAULT_WORD_LENGTH, fmt.Sprintf("number of letters per word (%d-%d)", MIN_WORD_LENGTH, MAX_WORD_LENGTH))
+	flags.IntVar(&options.Game.Guesses, "guesses", DEFAULT_GUESSES, fmt.Sprintf("number of allowed guesses (%d-%d)", MIN_GUESSES, MAX_GUESSES))
+	flags.BoolVar(&options.Game.Hard, "hard", false, "play in hard mode")
+	flags.StringVar(&options.Game.Lang, "lang", DEFAULT_LANGUAGE, fmt.Sprintf("language of the words, one of %s", strings.Join(languageCodes(), ", ")))
+	flags.IntVar(&options.Sample, "sample", 0, "number of solutions to play, drawn with the seed, 0 plays all of them")
+	flags.Int64Var(&options.Seed, "seed", 1, "seed of the sample and of random solvers")
+	flags.IntVar(&options.Workers, "workers", runtime.NumCPU(), "number of games played at once")
+	flags.IntVar(&options.Worst, "worst", 10, "number of hardest words to report")
+	flags.BoolVar(&cachePatterns, "cache-patterns", false, "keep the feedback patterns of the solvers in the data directory")
+	asJSON := flags.Bool("json", false, "print the report as JSON")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s bench [flags]\n", APP_NAME)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return fmt.Errorf("Error: Unexpected arguments %v", flags.Args())
+	}
+	if options.Sample < 0 {
+		return fmt.Errorf("Error: Sample size can't be negative")
+	}
+	if options.Worst < 0 {
+		return fmt.Errorf("Error: Number of hardest words can't be negative")
+	}
+	if _, err := NewSolver(options.Solver, options.Seed); err != nil {
+		return err
+	}
+
+	words, err := benchWords(options)
+	if err != nil {
+		return err
+	}
+	report, err := bench(options, words)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+	report.write(os.Stdout)
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func NewTestBenchOptions(solver string) BenchOptions {
+	return BenchOptions{Solver: solver, Game: DefaultOptions(), Sample: 6, Seed: 3, Workers: 3, Worst: 2}
+}
+
+func TestBenchWordsSample(t *testing.T) {
+	options := NewTestBenchOptions("backtrack")
+	a, err := benchWords(options)
+	if err != nil {
+		t.Fatalf("Expected words but got %s", err)
+	}
+	b, _ := benchWords(options)
+	if len(a) != options.Sample || !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected the same sample of %d words but got %v and %v", options.Sample, a, b)
+	}
+}
+
+func TestBenchDeterministic(t *testing.T) {
+	options := NewTestBenchOptions("random")
+	words, _ := benchWords(options)
+	a, err := bench(options, words)
+	if err != nil {
+		t.Fatalf("Expected a report but got %s", err)
+	}
+	options.Workers = 1
+	b, _ := bench(options, words)
+	a.Seconds, b.Seconds = 0, 0
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected the same report for the same seed but got %+v and %+v", a, b)
+	}
+}
+
+func TestBenchReport(t *testing.T) {
+	options := NewTestBenchOptions("backtrack")
+	report, err := bench(options, []string{"earth", "pilot", "adept"})
+	if err != nil {
+		t.Fatalf("Expected a report but got %s", err)
+	}
+	if report.Games != 3 || report.Wins+report.Failures != 3 || len(report.Failed) != report.Failures {
+		t.Errorf("Expected 3 games to be counted but got %+v", report)
+	}
+	wins := 0
+	for _, count := range report.Distribution {
+		wins += count
+	}
+	if wins != report.Wins {
+		t.Errorf("Expected the distribution to add up to %d wins but got %v", report.Wins, report.Distribution)
+	}
+	if len(report.Worst) != options.Worst {
+		t.Errorf("Expected %d worst words but got %v", options.Worst, report.Worst)
+	}
+	for i := 1; i < len(report.Worst); i++ {
+		if report.Worst[i].Solved && len(report.Worst[i].Guesses) > len(report.Worst[i-1].Guesses) {
+			t.Errorf("Expected the hardest words first but got %v", report.Worst)
+		}
+	}
+
+	var s strings.Builder
+	report.write(&s)
+	if !strings.Contains(s.String(), "Solver:   backtrack") {
+		t.Errorf("Expected the solver in the report but got\n%s", s.String())
+	}
+}
+
+func TestRunBenchInvalidFlags(t *testing.T) {
+	for _, args := range [][]string{{"--worst", "-1"}, {"--sample", "-1"}} {
+		if err := runBench(args); err == nil || !strings.HasPrefix(err.Error(), "Error: ") {
+			t.Errorf("Expected an error for %v but got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+// CandidateSet holds the solutions that are still consistent with the
+// feedback given so far. It lets the game postpone picking a solution, as
+// in absurdle, where every guess is answered with the feedback that keeps
+// the most candidates alive.
+type CandidateSet struct {
+	words []string
+}
+
+func NewCandidateSet(words []string) *CandidateSet {
+	return &CandidateSet{words: words}
+}
+
+func (c *CandidateSet) size() int {
+	return len(c.words)
+}
+
+// partition groups the candidates by the feedback pattern guess would
+// receive against them.
+func (c *CandidateSet) partition(guess string) map[int][]string {
+	buckets := make(map[int][]string)
+	for _, word := range c.words {
+		pattern := encodeFeedback(score(guess, word))
+		buckets[pattern] = append(buckets[pattern], word)
+	}
+	return buckets
+}
+
+// narrow keeps the largest bucket of candidates for guess and returns a
+// representative solution of it. Every word in the bucket produces the same
+// feedback, so the representative can be scored like a regular solution.
+// Ties are broken in favour of the pattern revealing the least.
+func (c *CandidateSet) narrow(guess string) string {
+	buckets := c.partition(guess)
+	best := -1
+	for pattern, bucket := range buckets {
+		if best == -1 || len(bucket) > len(buckets[best]) {
+			best = pattern
+			continue
+		}
+		if len(bucket) < len(buckets[best]) {
+			continue
+		}
+		strength, best_strength := patternStrength(pattern), patternStrength(best)
+		if strength < best_strength || strength == best_strength && pattern < best {
+			best = pattern
+		}
+	}
+	c.words = buckets[best]
+	return c.words[0]
+}
+
+// patternStrength weighs how much a feedback pattern reveals, counting a
+// green as two yellows.
+func patternStrength(pattern int) int {
+	strength := 0
+	for ; pattern > 0; pattern /= 3 {
+		strength += pattern % 3
+	}
+	return strength
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCandidateSetNarrow(t *testing.T) {
+	candidates := NewCandidateSet([]string{"earth", "heart", "hater", "adept"})
+	solution := candidates.narrow("tears")
+	// 'earth' and 'hater' share a pattern for 'tears'
+	if candidates.size() != 2 {
+		t.Errorf("Expected the largest bucket to be kept but got %v", candidates.words)
+	}
+	for _, word := range candidates.words {
+		if encodeFeedback(score("tears", word)) != encodeFeedback(score("tears", solution)) {
+			t.Errorf("Expected '%s' to share the feedback of '%s'", word, solution)
+		}
+	}
+}
+
+func TestCandidateSetNarrowTieBreak(t *testing.T) {
+	// 'crane' against 'crane' is all green, against 'pilot' all grey
+	candidates := NewCandidateSet([]string{"crane", "pilot"})
+	if solution := candidates.narrow("crane"); solution != "pilot" {
+		t.Errorf("Expected the pattern revealing the least to be kept but got '%s'", solution)
+	}
+}
+
+func TestAbsurdle(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = ABSURDLE
+	options.Guesses = MAX_GUESSES
+	wordle, err := NewWordle(options)
+	if err != nil {
+		t.Fatalf("Expected game to be created but got %s", err)
+	}
+	if wordle.solution != "" {
+		t.Errorf("Expected the solution not to be chosen before the first guess")
+	}
+
+	for wordle.status == ONGOING {
+		guess := wordle.suggestNextGuess()
+		if err := wordle.guess(guess); err != nil {
+			t.Fatalf("Expected guess '%s' to be successful but got %s", guess, err)
+		}
+		for _, word := range wordle.adversary.words {
+			if !wordle.validateFull(mustGuess(t, word)) {
+				t.Fatalf("Expected candidate '%s' to be consistent with the board: %s", word, wordle.message)
+			}
+		}
+	}
+	if wordle.status == WIN && wordle.adversary.size() != 1 {
+		t.Errorf("Expected a single candidate to be left after winning")
+	}
+}
+
+func mustGuess(t *testing.T, word string) Guess {
+	guess, err := NewGuess(word, LANGUAGES[DEFAULT_LANGUAGE], len(word))
+	if err != nil {
+		t.Fatalf("Expected '%s' to be a valid guess but got %s", word, err)
+	}
+	return guess
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Challenge codes let a player pick a solution and share it without giving
+// it away. A code holds a version, a random nonce and the obfuscated game:
+//
+//	version | nonce | flags, guesses, size, limit, limit, solution..., language..., checksum, checksum
+//
+// The flags hold hard mode, whether a language follows and the mode the word
+// is played in. That is classic or countdown, the other modes pick their own
+// solution or have none. The time limit in seconds is only there for a
+// countdown. The size is the number of bytes of the UTF-8 solution, the
+// language code only follows it for games in a language other than English.
+// Everything after the nonce is XORed with a keystream derived from the
+// nonce, so the same word produces a different code every time.
+
+const CHALLENGE_VERSION = 1
+
+const (
+	challengeFlagHard     = 1 << 0
+	challengeFlagLanguage = 1 << 1
+	challengeModeShift    = 2
+	challengeModeMask     = 0b111 << challengeModeShift
+)
+
+// CHALLENGE_MAX_LIMIT is the longest countdown a challenge code can hold.
+const CHALLENGE_MAX_LIMIT = math.MaxUint16 * time.Second
+
+var (
+	challengeKey      = []byte("wordle-tui/challenge")
+	challengeEncoding = base32.NewEncoding("0123456789ABCDEFGHJKMNPQRSTVWXYZ").WithPadding(base32.NoPadding)
+)
+
+func challengeKeystream(nonce byte, length int) []byte {
+	stream := make([]byte, 0, length)
+	for block := byte(0); len(stream) < length; block++ {
+		sum := sha256.Sum256(append(append([]byte{}, challengeKey...), nonce, block))
+		stream = append(stream, sum[:]...)
+	}
+	return stream[:length]
+}
+
+func challengeChecksum(payload []byte) []byte {
+	sum := sha256.Sum256(payload)
+	return sum[:2]
+}
+
+// encodeChallenge returns the challenge code for a game of options with the
+// given solution.
+func encodeChallenge(solution string, options Options) string {
+	return encodeChallengeNonce(solution, options, byte(rand.Intn(256)))
+}
+
+func encodeChallengeNonce(solution string, options Options, nonce byte) string {
+	flags := byte(0)
+	if options.Hard {
+		flags |= challengeFlagHard
+	}
+	lang := options.Lang
+	if lang == DEFAULT_LANGUAGE {
+		lang = ""
+	}
+	if lang != "" {
+		flags |= challengeFlagLanguage
+	}
+	if options.Mode == COUNTDOWN {
+		flags |= byte(COUNTDOWN) << challengeModeShift
+	}
+	payload := []byte{flags, byte(options.Guesses), byte(len(solution))}
+	if options.Mode == COUNTDOWN {
+		payload = binary.BigEndian.AppendUint16(payload, uint16(options.Limit/time.Second))
+	}
+	payload = append(payload, solution...)
+	payload = append(payload, lang...)
+	payload = append(payload, challengeChecksum(payload)...)
+
+	for i, key := range challengeKeystream(nonce, len(payload)) {
+		payload[i] ^= key
+	}
+
+	code := challengeEncoding.EncodeToString(append([]byte{CHALLENGE_VERSION, nonce}, payload...))
+	groups := make([]string, 0, len(code)/4+1)
+	for len(code) > 4 {
+		groups = append(groups, code[:4])
+		code = code[4:]
+	}
+	return strings.Join(append(groups, code), "-")
+}
+
+// decodeChallenge returns the options for the game described by code. Codes
+// are case insensitive and dashes and spaces are ignored.
+func decodeChallenge(code string) (Options, error) {
+	options := DefaultOptions()
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	data, err := challengeEncoding.DecodeString(code)
+	if err != nil || len(data) < 7 {
+		return options, fmt.Errorf("Error: Invalid challenge code")
+	}
+	if data[0] != CHALLENGE_VERSION {
+		return options, fmt.Errorf("Error: Unsupported challenge code version %d", data[0])
+	}
+
+	nonce, payload := data[1], data[2:]
+	for i, key := range challengeKeystream(nonce, len(payload)) {
+		payload[i] ^= key
+	}
+	checksum := payload[len(payload)-2:]
+	payload = payload[:len(payload)-2]
+	if !bytes.Equal(challengeChecksum(payload), checksum) {
+		return options, fmt.Errorf("Error: Invalid challenge code")
+	}
+
+	flags, guesses, size, rest := payload[0], int(payload[1]), int(payload[2]), payload[3:]
+	switch Mode(flags & challengeModeMask >> challengeModeShift) {
+	case CLASSIC:
+		options.Mode = CHALLENGE
+	case COUNTDOWN:
+		if len(rest) < 2 {
+			return options, fmt.Errorf("Error: Invalid challenge code")
+		}
+		options.Mode = COUNTDOWN
+		options.Limit = time.Duration(binary.BigEndian.Uint16(rest)) * time.Second
+		rest = rest[2:]
+	default:
+		return options, fmt.Errorf("Error: Invalid challenge code")
+	}
+	if len(rest) < size {
+		return options, fmt.Errorf("Error: Invalid challenge code")
+	}
+	solution, lang := rest[:size], rest[size:]
+	if (flags&challengeFlagLanguage != 0) != (len(lang) > 0) || !utf8.Valid(solution) {
+		return options, fmt.Errorf("Error: Invalid challenge code")
+	}
+	if _, err := language(string(lang)); err != nil {
+		return options, err
+	}
+	options.Solution = string(solution)
+	options.Lang = string(lang)
+	options.Length = utf8.RuneCount(solution)
+	options.Guesses = guesses
+	options.Hard = flags&challengeFlagHard != 0
+	return options, nil
+}
+
+// newChallenge validates word against the guess dictionary and returns its
+// challenge code.
+func newChallenge(word string, options Options) (string, error) {
+	word = strings.ToLower(word)
+	options.Length = utf8.RuneCountInString(word)
+	if options.Length < MIN_WORD_LENGTH || options.Length > MAX_WORD_LENGTH {
+		return "", fmt.Errorf("Error: Word length has to be between %d and %d", MIN_WORD_LENGTH, MAX_WORD_LENGTH)
+	}
+	if options.Guesses < MIN_GUESSES || options.Guesses > MAX_GUESSES {
+		return "", fmt.Errorf("Error: Number of guesses has to be between %d and %d", MIN_GUESSES, MAX_GUESSES)
+	}
+	switch options.Mode {
+	case CLASSIC, CHALLENGE:
+	case COUNTDOWN:
+		if options.Limit < time.Second || options.Limit > CHALLENGE_MAX_LIMIT {
+			return "", fmt.Errorf("Error: Time limit has to be between 1s and %s", CHALLENGE_MAX_LIMIT)
+		}
+	default:
+		return "", fmt.Errorf("Error: Challenges are played in classic or countdown mode")
+	}
+	lang, err := language(options.Lang)
+	if err != nil {
+		return "", err
+	}
+	_, guessTrie, err := loadTries(lang, options.Length)
+	if err != nil {
+		return "", err
+	}
+	if _, err := NewGuess(word, lang, options.Length); err != nil || !guessTrie.findWord(word) {
+		return "", fmt.Errorf("Error: '%s' is not a valid word", word)
+	}
+	return encodeChallenge(word, options), nil
+}
+
+// runChallenge implements the challenge command, printing the code for a
+// word given on the command line.
+func runChallenge(args []string) error {
+	options := DefaultOptions()
+	flags := flag.NewFlagSet("challenge", flag.ContinueOnError)
+	flags.BoolVar(&options.Hard, "hard", false, "require hard mode for the challenge")
+	flags.IntVar(&options.Guesses, "guesses", DEFAULT_GUESSES, fmt.Sprintf("number of allowed guesses (%d-%d)", MIN_GUESSES, MAX_GUESSES))
+	flags.StringVar(&options.Lang, "lang", DEFAULT_LANGUAGE, fmt.Sprintf("language of the word, one of %s", strings.Join(languageCodes(), ", ")))
+	flags.Func("mode", "mode the word is played in, 'classic' or 'countdown'", func(name string) error {
+		mode, err := parseMode(name)
+		options.Mode = mode
+		return err
+	})
+	flags.DurationVar(&options.Limit, "time", COUNTDOWN_LIMIT, "time limit of a countdown")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s challenge [flags] WORD\n", APP_NAME)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("Error: Expected exactly one word")
+	}
+
+	code, err := newChallenge(flags.Arg(0), options)
+	if err != nil {
+		return err
+	}
+	fmt.Println(code)
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChallengeRoundTrip(t *testing.T) {
+	options := DefaultOptions()
+	options.Guesses = 4
+	options.Hard = true
+	code, err := newChallenge("Earth", options)
+	if err != nil {
+		t.Fatalf("Expected a challenge code but got %s", err)
+	}
+	if strings.Contains(strings.ToLower(code), "earth") {
+		t.Errorf("Expected the solution not to be readable from '%s'", code)
+	}
+
+	decoded, err := decodeChallenge(strings.ToLower(code))
+	if err != nil {
+		t.Fatalf("Expected '%s' to be decoded but got %s", code, err)
+	}
+	if decoded.Solution != "earth" || decoded.Length != 5 || decoded.Guesses != 4 || !decoded.Hard || decoded.Mode != CHALLENGE {
+		t.Errorf("Expected decoded options to match the challenge but got %+v", decoded)
+	}
+
+	game, err := NewGame(decoded)
+	if err != nil {
+		t.Fatalf("Expected game to be created but got %s", err)
+	}
+	if game.boards[0].solution != "earth" {
+		t.Errorf("Expected the challenge solution to be used")
+	}
+}
+
+func TestChallengeLengths(t *testing.T) {
+	for _, word := range []string{"word", "bridge", "airport", "absolute"} {
+		code, err := newChallenge(word, DefaultOptions())
+		if err != nil {
+			t.Fatalf("Expected a challenge code for '%s' but got %s", word, err)
+		}
+		decoded, err := decodeChallenge(code)
+		if err != nil || decoded.Solution != word || decoded.Length != len(word) {
+			t.Errorf("Expected '%s' to survive the round trip but got %+v (%v)", word, decoded, err)
+		}
+	}
+}
+
+func TestChallengeLanguage(t *testing.T) {
+	options := DefaultOptions()
+	options.Lang = "de"
+	code, err := newChallenge("Größe", options)
+	if err != nil {
+		t.Fatalf("Expected a challenge code but got %s", err)
+	}
+	decoded, err := decodeChallenge(code)
+	if err != nil || decoded.Solution != "größe" || decoded.Length != 5 || decoded.Lang != "de" {
+		t.Errorf("Expected the language to survive the round trip but got %+v (%v)", decoded, err)
+	}
+	if _, err := newChallenge("größe", DefaultOptions()); err == nil {
+		t.Errorf("Expected an error for a German word in English")
+	}
+	if decoded, _ := decodeChallenge(encodeChallengeNonce("earth", DefaultOptions(), 7)); decoded.Lang != "" {
+		t.Errorf("Expected English challenges to carry no language but got '%s'", decoded.Lang)
+	}
+}
+
+func TestChallengeCountdown(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = COUNTDOWN
+	options.Limit = 90 * time.Second
+	code, err := newChallenge("earth", options)
+	if err != nil {
+		t.Fatalf("Expected a challenge code but got %s", err)
+	}
+	decoded, err := decodeChallenge(code)
+	if err != nil || decoded.Mode != COUNTDOWN || decoded.Limit != 90*time.Second || decoded.Solution != "earth" {
+		t.Fatalf("Expected the countdown to survive the round trip but got %+v (%v)", decoded, err)
+	}
+	game, err := NewGame(decoded)
+	if err != nil || game.boards[0].solution != "earth" {
+		t.Errorf("Expected a countdown on the challenge word but got %v", err)
+	}
+
+	options.Limit = 0
+	if _, err := newChallenge("earth", options); err == nil {
+		t.Errorf("Expected an error for a countdown without a time limit")
+	}
+	options.Mode = ABSURDLE
+	if _, err := newChallenge("earth", options); err == nil {
+		t.Errorf("Expected an error for a mode that picks its own solution")
+	}
+	// other modes are played as a classic challenge, e.g. for saved games
+	options.Mode = DAILY
+	if decoded, _ := decodeChallenge(encodeChallenge("earth", options)); decoded.Mode != CHALLENGE {
+		t.Errorf("Expected a classic challenge but got %s", decoded.Mode)
+	}
+}
+
+func TestChallengeInvalid(t *testing.T) {
+	if _, err := newChallenge("zzzzz", DefaultOptions()); err == nil {
+		t.Errorf("Expected an error for a word not in the dictionary")
+	}
+
+	code := encodeChallengeNonce("earth", DefaultOptions(), 42)
+	if _, err := decodeChallenge(code); err != nil {
+		t.Fatalf("Expected '%s' to be decoded but got %s", code, err)
+	}
+	tampered := []byte(code)
+	if tampered[6] == '0' {
+		tampered[6] = '1'
+	} else {
+		tampered[6] = '0'
+	}
+	if _, err := decodeChallenge(string(tampered)); err == nil {
+		t.Errorf("Expected an error for a tampered code")
+	}
+	if _, err := decodeChallenge("not a code!"); err == nil {
+		t.Errorf("Expected an error for garbage")
+	}
+}
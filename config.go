@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const CONFIG_FILE = "config.json"
+
+// Config holds the settings of config.json in the config directory, e.g.
+//
+//	{"solutions": "jargon.txt", "guess_list": "/usr/share/dict/jargon.csv"}
+//
+// Relative paths are resolved against the config directory. Flags take
+// precedence over the config, the keys are named after them.
+type Config struct {
+	Solutions string `json:"solutions"` // word list files, see loadWordList
+	GuessList string `json:"guess_list"`
+}
+
+// configDir returns the directory for configuration files as specified by the
+// XDG base directory specification.
+func configDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, APP_NAME), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", APP_NAME), nil
+}
+
+// loadConfig reads the config, a missing file is an empty config.
+func loadConfig() (Config, error) {
+	var config Config
+	dir, err := configDir()
+	if err != nil {
+		return config, err
+	}
+	path := filepath.Join(dir, CONFIG_FILE)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("Error: Invalid config %s: %w", path, err)
+	}
+	for _, file := range []*string{&config.Solutions, &config.GuessList} {
+		if *file != "" && !filepath.IsAbs(*file) {
+			*file = filepath.Join(dir, *file)
+		}
+	}
+	return config, nil
+}
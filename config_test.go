@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	config, err := loadConfig()
+	if err != nil || config != (Config{}) {
+		t.Fatalf("Expected an empty config without a file but got %+v (%v)", config, err)
+	}
+
+	path := filepath.Join(dir, APP_NAME, CONFIG_FILE)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"solutions": "jargon.txt", "guess_list": "/usr/share/jargon.csv"}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if config, err = loadConfig(); err != nil {
+		t.Fatal(err)
+	}
+	if config.Solutions != filepath.Join(dir, APP_NAME, "jargon.txt") || config.GuessList != "/usr/share/jargon.csv" {
+		t.Errorf("Expected relative paths to be resolved against the config directory but got %+v", config)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadConfig(); err == nil {
+		t.Errorf("Expected an error for an invalid config")
+	}
+}
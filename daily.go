@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// DAILY_EPOCH is the date of daily puzzle #1, every day after it gets the
+// next number.
+var DAILY_EPOCH = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+const (
+	DAILY_SEED    = 20240101
+	DAILY_FILE    = "daily.json"
+	DAILY_VERSION = 2
+)
+
+// dailyNumber returns the number of the daily puzzle for the calendar day of
+// date in its own location.
+func dailyNumber(date time.Time) (int, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(day.Sub(DAILY_EPOCH).Hours() / 24)
+	if days < 0 {
+		return 0, fmt.Errorf("Error: The first daily puzzle is from %s", DAILY_EPOCH.Format(time.DateOnly))
+	}
+	return days + 1, nil
+}
+
+// parseDailyDate parses a date in the YYYY-MM-DD format and returns the
+// number of its daily puzzle. Puzzles from the future are not available.
+func parseDailyDate(value string, today time.Time) (int, error) {
+	date, err := time.ParseInLocation(time.DateOnly, value, today.Location())
+	if err != nil {
+		return 0, fmt.Errorf("Error: Date has to be in the format YYYY-MM-DD")
+	}
+	if date.After(today) {
+		return 0, fmt.Errorf("Error: The daily puzzle for %s is not out yet", value)
+	}
+	return dailyNumber(date)
+}
+
+// dailySolution picks the solution of a daily puzzle. The words are played
+// in a fixed random order, a new order is used for each cycle through the
+// list, so no word is repeated within a cycle.
+func dailySolution(words []string, number int) string {
+	cycle := (number - 1) / len(words)
+	order := rand.New(rand.NewSource(DAILY_SEED + int64(cycle))).Perm(len(words))
+	return words[order[(number-1)%len(words)]]
+}
+
+// dailyPuzzle is the progress on a daily puzzle. The guess budget and the
+// status are kept with the guesses, so a lost puzzle can't be continued with
+// more guesses.
+type dailyPuzzle struct {
+	Guesses []string `json:"guesses"`
+	Budget  int      `json:"budget"` // unknown for puzzles of version 1
+	Status  string   `json:"status"`
+}
+
+type dailyRecord struct {
+	Version int                    `json:"version"`
+	Puzzles map[string]dailyPuzzle `json:"puzzles"` // puzzle key -> progress
+}
+
+// dailyKey identifies a daily puzzle by its number, word length and
+// language, e.g. "5/100" or "de/5/100". Custom word lists have puzzles of
+// their own, told apart by a checksum of the lists.
+func dailyKey(options Options) string {
+	key := fmt.Sprintf("%d/%d", options.Length, options.Puzzle)
+	lang := options.Lang
+	if lang == "" {
+		lang = DEFAULT_LANGUAGE
+	}
+	if lang != DEFAULT_LANGUAGE {
+		key = lang + "/" + key
+	}
+	if checksum, ok := customChecksum(dictionaryKey{lang: lang, length: options.Length}); ok {
+		key += fmt.Sprintf("/%x", checksum[:4])
+	}
+	return key
+}
+
+// loadDailyRecord reads the daily record, version 1 only kept the guesses of
+// each puzzle.
+func loadDailyRecord() (dailyRecord, error) {
+	record := dailyRecord{Version: DAILY_VERSION, Puzzles: make(map[string]dailyPuzzle)}
+	var stored struct {
+		Version int                        `json:"version"`
+		Puzzles map[string]json.RawMessage `json:"puzzles"`
+	}
+	if err := readData(DAILY_FILE, &stored); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return record, err
+	}
+	if stored.Version > DAILY_VERSION {
+		return record, fmt.Errorf("Error: Daily puzzles were recorded by a newer version (%d)", stored.Version)
+	}
+	for key, data := range stored.Puzzles {
+		var puzzle dailyPuzzle
+		var err error
+		if stored.Version < 2 {
+			err = json.Unmarshal(data, &puzzle.Guesses)
+		} else {
+			err = json.Unmarshal(data, &puzzle)
+		}
+		if err != nil {
+			return record, err
+		}
+		record.Puzzles[key] = puzzle
+	}
+	return record, nil
+}
+
+// restoreDaily replays the guesses already made on the daily puzzle of game,
+// so a daily puzzle can't be restarted for a fresh result. A puzzle is only
+// continued with the guess budget it was started with.
+func restoreDaily(game *Game) error {
+	record, err := loadDailyRecord()
+	if err != nil {
+		return err
+	}
+	puzzle, ok := record.Puzzles[dailyKey(game.options)]
+	if !ok {
+		return nil
+	}
+	if puzzle.Budget != 0 && puzzle.Budget != game.options.Guesses {
+		return fmt.Errorf("Error: Daily puzzle #%d is played with %d guesses", game.options.Puzzle, puzzle.Budget)
+	}
+	if err := game.replay(puzzle.Guesses); err != nil {
+		return err
+	}
+	if puzzle.Status != "" && puzzle.Status != statusNames[game.status] {
+		return fmt.Errorf("Error: Daily puzzle #%d could not be restored", game.options.Puzzle)
+	}
+	return nil
+}
+
+// saveDaily records the guesses made on the daily puzzle of game.
+func saveDaily(game *Game) error {
+	record, err := loadDailyRecord()
+	if err != nil {
+		return err
+	}
+	record.Version = DAILY_VERSION
+	record.Puzzles[dailyKey(game.options)] = dailyPuzzle{
+		Guesses: game.history,
+		Budget:  game.options.Guesses,
+		Status:  statusNames[game.status],
+	}
+	return writeData(DAILY_FILE, record)
+}
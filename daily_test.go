@@ -0,0 +1,162 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDailyNumber(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		expected int
+	}{
+		{time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2024, time.January, 1, 23, 59, 0, 0, time.Local), 1},
+		{time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC), 61},
+		{time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), 367},
+	}
+	for _, test := range tests {
+		number, err := dailyNumber(test.date)
+		if err != nil || number != test.expected {
+			t.Errorf("Expected puzzle %d for %s but got %d (%v)", test.expected, test.date, number, err)
+		}
+	}
+
+	if _, err := dailyNumber(time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Errorf("Expected an error for a date before the first puzzle")
+	}
+}
+
+func TestParseDailyDate(t *testing.T) {
+	today := time.Date(2024, time.February, 1, 10, 0, 0, 0, time.UTC)
+	if number, err := parseDailyDate("2024-02-01", today); err != nil || number != 32 {
+		t.Errorf("Expected puzzle 32 but got %d (%v)", number, err)
+	}
+	if _, err := parseDailyDate("2024-02-02", today); err == nil {
+		t.Errorf("Expected an error for a puzzle from the future")
+	}
+	if _, err := parseDailyDate("01.02.2024", today); err == nil {
+		t.Errorf("Expected an error for an invalid date")
+	}
+}
+
+func TestDailySolutionNoRepeats(t *testing.T) {
+	trie, _, err := loadTries(LANGUAGES[DEFAULT_LANGUAGE], DEFAULT_WORD_LENGTH)
+	if err != nil {
+		t.Fatalf("Expected tries to be loaded but got %s", err)
+	}
+	words := trie.words()
+	seen := make(map[string]bool, len(words))
+	for number := 1; number <= len(words); number++ {
+		solution := dailySolution(words, number)
+		if seen[solution] {
+			t.Fatalf("Expected no repeats within a cycle but '%s' repeats at puzzle %d", solution, number)
+		}
+		seen[solution] = true
+	}
+	if dailySolution(words, 42) != dailySolution(words, 42) {
+		t.Errorf("Expected daily solutions to be deterministic")
+	}
+}
+
+func TestRestoreDaily(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Mode = DAILY
+	options.Puzzle = 100
+
+	game, err := NewGame(options)
+	if err != nil {
+		t.Fatalf("Expected game to be created but got %s", err)
+	}
+	if err := game.guess("adept"); err != nil {
+		t.Fatalf("Expected guess to be successful but got %s", err)
+	}
+	if err := saveDaily(game); err != nil {
+		t.Fatalf("Expected daily to be saved but got %s", err)
+	}
+
+	restored, err := NewGame(options)
+	if err != nil {
+		t.Fatalf("Expected game to be created but got %s", err)
+	}
+	if err := restoreDaily(restored); err != nil {
+		t.Fatalf("Expected daily to be restored but got %s", err)
+	}
+	if restored.attempt != 1 || restored.boards[0].solution != game.boards[0].solution {
+		t.Errorf("Expected the daily puzzle to continue where it was left")
+	}
+
+	options.Puzzle = 101
+	other, _ := NewGame(options)
+	if err := restoreDaily(other); err != nil || other.attempt != 0 {
+		t.Errorf("Expected a different daily puzzle to start fresh")
+	}
+}
+
+func TestRestoreDailyBudget(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Mode = DAILY
+	options.Puzzle = 100
+	options.Guesses = 1
+
+	game, _ := NewGame(options)
+	game.boards[0].solution = "earth"
+	if err := game.guess("adept"); err != nil || game.status != LOSE {
+		t.Fatalf("Expected the daily puzzle to be lost but got %v", err)
+	}
+	if err := saveDaily(game); err != nil {
+		t.Fatalf("Expected daily to be saved but got %s", err)
+	}
+
+	options.Guesses = 6
+	more, _ := NewGame(options)
+	if err := restoreDaily(more); err == nil || more.status != ONGOING || more.attempt != 0 {
+		t.Errorf("Expected a lost daily puzzle not to be continued with more guesses")
+	}
+	options.Guesses = 1
+	same, _ := NewGame(options)
+	same.boards[0].solution = "earth"
+	if err := restoreDaily(same); err != nil || same.status != LOSE {
+		t.Errorf("Expected the lost daily puzzle to be restored but got %v", err)
+	}
+}
+
+func TestDailyKey(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = DAILY
+	options.Puzzle = 100
+	if key := dailyKey(options); key != "5/100" {
+		t.Errorf("Expected the English daily to keep its key but got '%s'", key)
+	}
+	options.Lang = "de"
+	if key := dailyKey(options); key != "de/5/100" {
+		t.Errorf("Expected the language in the key but got '%s'", key)
+	}
+
+	en := LANGUAGES[DEFAULT_LANGUAGE]
+	useWordLists(en, 5, []string{"earth", "heart"}, nil)
+	t.Cleanup(func() { useWordLists(en, 5, nil, nil) })
+	options.Lang = ""
+	custom := dailyKey(options)
+	useWordLists(en, 5, []string{"earth", "hater"}, nil)
+	if !strings.HasPrefix(custom, "5/100/") || dailyKey(options) == custom {
+		t.Errorf("Expected every word list to have its own daily puzzles but got '%s'", custom)
+	}
+}
+
+func TestLoadDailyRecordVersion1(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	if err := writeData(DAILY_FILE, map[string]any{"version": 1, "puzzles": map[string][]string{"5/100": {"adept"}}}); err != nil {
+		t.Fatal(err)
+	}
+	record, err := loadDailyRecord()
+	if err != nil {
+		t.Fatalf("Expected the old record to be read but got %s", err)
+	}
+	if puzzle := record.Puzzles["5/100"]; len(puzzle.Guesses) != 1 || puzzle.Budget != 0 {
+		t.Errorf("Expected the guesses of the old record but got %+v", puzzle)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// feedbackPattern is encodeFeedback(score(guess, solution)) without
+// allocations, for use in the hot loops of the solver. Words with letters
+// outside of ASCII are compared rune by rune.
+func feedbackPattern(guess string, solution string) int {
+	if len(guess) != len(solution) {
+		return runePattern([]rune(guess), []rune(solution))
+	}
+	green := 0
+	for i := 0; i < len(guess); i++ {
+		if guess[i] >= utf8.RuneSelf || solution[i] >= utf8.RuneSelf {
+			return runePattern([]rune(guess), []rune(solution))
+		}
+		if guess[i] == solution[i] {
+			green |= 1 << i
+		}
+	}
+	// solution letters are used up by greens and earlier yellows
+	used := green
+	pattern, digit := 0, 1
+	for i := 0; i < len(guess); i++ {
+		if green&(1<<i) != 0 {
+			pattern += 2 * digit
+		} else {
+			for j := 0; j < len(solution); j++ {
+				if used&(1<<j) == 0 && guess[i] == solution[j] {
+					used |= 1 << j
+					pattern += digit
+					break
+				}
+			}
+		}
+		digit *= 3
+	}
+	return pattern
+}
+
+// runePattern is feedbackPattern for words as runes.
+func runePattern(guess []rune, solution []rune) int {
+	green := 0
+	for i := range guess {
+		if guess[i] == solution[i] {
+			green |= 1 << i
+		}
+	}
+	used := green
+	pattern, digit := 0, 1
+	for i := range guess {
+		if green&(1<<i) != 0 {
+			pattern += 2 * digit
+		} else {
+			for j := range solution {
+				if used&(1<<j) == 0 && guess[i] == solution[j] {
+					used |= 1 << j
+					pattern += digit
+					break
+				}
+			}
+		}
+		digit *= 3
+	}
+	return pattern
+}
+
+func patternCount(length int) int {
+	return int(math.Pow(3, float64(length)))
+}
+
+// entropy returns the expected information in bits of a guess with the
+// given patterns against candidates the solution is uniformly drawn from.
+// counts is scratch space of patternCount entries that is left zeroed.
+func entropy(patterns []int, solved int, counts []int) float64 {
+	if len(patterns) == 0 {
+		return 0
+	}
+	used := make([]int, 0, 64)
+	for _, pattern := range patterns {
+		if counts[pattern] == 0 {
+			used = append(used, pattern)
+		}
+		counts[pattern]++
+	}
+	bits := 0.0
+	total := float64(len(patterns))
+	for _, pattern := range used {
+		p := float64(counts[pattern]) / total
+		bits -= p * math.Log2(p)
+		counts[pattern] = 0
+	}
+	return bits
+}
+
+// worstBucket returns the number of candidates left in the worst case after
+// a guess with the given patterns. Guessing the solution, the solved
+// pattern, leaves none.
+func worstBucket(patterns []int, solved int, counts []int) float64 {
+	used := make([]int, 0, 64)
+	worst := 0
+	for _, pattern := range patterns {
+		if counts[pattern] == 0 {
+			used = append(used, pattern)
+		}
+		counts[pattern]++
+		if pattern != solved && counts[pattern] > worst {
+			worst = counts[pattern]
+		}
+	}
+	for _, pattern := range used {
+		counts[pattern] = 0
+	}
+	return float64(worst)
+}
+
+// expectedSize returns the expected number of candidates left after a guess
+// with the given patterns when the solution is uniformly drawn from the
+// candidates.
+func expectedSize(patterns []int, solved int, counts []int) float64 {
+	if len(patterns) == 0 {
+		return 0
+	}
+	used := make([]int, 0, 64)
+	for _, pattern := range patterns {
+		if counts[pattern] == 0 {
+			used = append(used, pattern)
+		}
+		counts[pattern]++
+	}
+	sum := 0
+	for _, pattern := range used {
+		if pattern != solved {
+			sum += counts[pattern] * counts[pattern]
+		}
+		counts[pattern] = 0
+	}
+	return float64(sum) / float64(len(patterns))
+}
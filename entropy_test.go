@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func testPatterns(guess string, candidates ...string) []int {
+	patterns := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		patterns[i] = feedbackPattern(guess, candidate)
+	}
+	return patterns
+}
+
+func TestFeedbackPattern(t *testing.T) {
+	words := []string{"earth", "geese", "eerie", "speed", "abide", "error", "rarer", "llama", "allow"}
+	for _, guess := range words {
+		for _, solution := range words {
+			expected := encodeFeedback(score(guess, solution))
+			if pattern := feedbackPattern(guess, solution); pattern != expected {
+				t.Errorf("Expected pattern %d for '%s' against '%s' but got %d", expected, guess, solution, pattern)
+			}
+		}
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	counts := make([]int, patternCount(5))
+	// every candidate gives a different pattern
+	if bits := entropy(testPatterns("earth", "earth", "pilot", "heart", "adept"), 242, counts); math.Abs(bits-2) > 1e-9 {
+		t.Errorf("Expected 2 bits but got %f", bits)
+	}
+	// no candidate can be told apart
+	if bits := entropy(testPatterns("quick", "earth", "heart"), 242, counts); bits != 0 {
+		t.Errorf("Expected 0 bits but got %f", bits)
+	}
+	for _, count := range counts {
+		if count != 0 {
+			t.Fatalf("Expected scratch counts to be left zeroed")
+		}
+	}
+}
+
+func TestWorstBucket(t *testing.T) {
+	counts := make([]int, patternCount(5))
+	if worst := worstBucket(testPatterns("quick", "earth", "heart", "hater"), 242, counts); worst != 3 {
+		t.Errorf("Expected 3 candidates in the worst case but got %f", worst)
+	}
+	// guessing the solution leaves nothing
+	if worst := worstBucket(testPatterns("earth", "earth"), 242, counts); worst != 0 {
+		t.Errorf("Expected no candidates in the worst case but got %f", worst)
+	}
+}
+
+func TestExpectedSize(t *testing.T) {
+	counts := make([]int, patternCount(5))
+	if size := expectedSize(testPatterns("quick", "earth", "heart", "hater"), 242, counts); size != 3 {
+		t.Errorf("Expected 3 candidates left but got %f", size)
+	}
+	if size := expectedSize(testPatterns("earth", "earth", "pilot", "heart", "adept"), 242, counts); size != 0.75 {
+		t.Errorf("Expected 0.75 candidates left but got %f", size)
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+)
+
+var BOARD_COUNTS = []int{1, 2, 4, 8}
+
+// defaultGuesses returns the usual guess budget for the number of boards,
+// e.g. 6 for a single board and 9 for four boards.
+func defaultGuesses(boards int) int {
+	if boards <= 1 {
+		return DEFAULT_GUESSES
+	}
+	return boards + 5
+}
+
+// Game applies every guess to one or more independent Wordle boards, each
+// with its own solution. It ends when every board is solved or the guesses
+// run out.
+type Game struct {
+	boards  []*Wordle
+	options Options
+	attempt int
+	status  GameStatus
+	history []string
+	message string
+}
+
+func NewGame(options Options) (*Game, error) {
+	if options.Boards == 0 {
+		options.Boards = 1
+	}
+	valid := false
+	for _, count := range BOARD_COUNTS {
+		valid = valid || count == options.Boards
+	}
+	if !valid {
+		return nil, fmt.Errorf("Error: Number of boards has to be one of %v", BOARD_COUNTS)
+	}
+	if options.Mode == DAILY && options.Boards != 1 {
+		return nil, fmt.Errorf("Error: The daily puzzle is played on a single board")
+	}
+	if options.Mode == CHALLENGE && options.Boards != 1 {
+		return nil, fmt.Errorf("Error: Challenges are played on a single board")
+	}
+	if options.Solution != "" && options.Boards != 1 {
+		// the solution would only be given to the first board
+		return nil, fmt.Errorf("Error: A given solution is played on a single board")
+	}
+	if options.Mode == ABSURDLE && options.Boards != 1 {
+		return nil, fmt.Errorf("Error: Absurdle is played on a single board")
+	}
+	if options.Mode == ASSIST && options.Boards != 1 {
+		return nil, fmt.Errorf("Error: The assistant works on a single board")
+	}
+	if options.timed() && options.Limit <= 0 {
+		return nil, fmt.Errorf("Error: Timed games need a time limit")
+	}
+
+	boards := make([]*Wordle, options.Boards)
+	solutions := make(map[string]bool, options.Boards)
+	for i := range boards {
+		wordle, err := NewWordle(options)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 && wordle.adversary == nil && len(wordle.trie.words()) < options.Boards {
+			// the boards could never get a solution of their own
+			return nil, fmt.Errorf("Error: Not enough solutions for %d boards", options.Boards)
+		}
+		for wordle.adversary == nil && solutions[wordle.solution] {
+			wordle.solution = wordle.trie.randomWord()
+		}
+		solutions[wordle.solution] = true
+		boards[i] = wordle
+	}
+
+	return &Game{
+		boards:  boards,
+		options: options,
+		attempt: 0,
+		status:  ONGOING,
+		history: make([]string, 0, options.Guesses),
+	}, nil
+}
+
+func (g *Game) length() int {
+	return g.options.Length
+}
+
+func (g *Game) language() *Language {
+	return g.boards[0].lang
+}
+
+// guess submits word to every unsolved board. The guess is checked against
+// all of them first, so it is either applied everywhere or nowhere.
+func (g *Game) guess(word string) error {
+	if g.status != ONGOING {
+		g.message = "the game is over"
+		return fmt.Errorf("Error: Game is over")
+	}
+	if err := g.check(word); err != nil {
+		return err
+	}
+	for _, board := range g.unsolved() {
+		if err := board.guess(word); err != nil {
+			g.message = board.message
+			return err
+		}
+	}
+	g.advance(word)
+	return nil
+}
+
+// enter submits word with feedback entered by the player, see Wordle.enter.
+func (g *Game) enter(word string, feedback []Feedback) error {
+	if g.status != ONGOING {
+		g.message = "the game is over"
+		return fmt.Errorf("Error: Game is over")
+	}
+	board := g.boards[0]
+	if err := board.enter(word, feedback); err != nil {
+		g.message = board.message
+		return err
+	}
+	g.advance(word)
+	return nil
+}
+
+// advance records word once it was applied to the boards and checks whether
+// the game is over.
+func (g *Game) advance(word string) {
+	g.attempt++
+	g.history = append(g.history, word)
+	g.message = ""
+	if len(g.unsolved()) == 0 {
+		g.status = WIN
+		for _, board := range g.boards {
+			if board.status == LOSE {
+				g.status = LOSE
+			}
+		}
+	}
+}
+
+// timeout ends the game when the time runs out, the unsolved boards are
+// lost.
+func (g *Game) timeout() {
+	if g.status != ONGOING {
+		return
+	}
+	for _, board := range g.unsolved() {
+		board.status = LOSE
+	}
+	g.status = LOSE
+	g.message = "time is up"
+}
+
+// check reports whether word can be guessed on every unsolved board.
+func (g *Game) check(word string) error {
+	for _, board := range g.unsolved() {
+		if _, err := board.check(word); err != nil {
+			g.message = board.message
+			return err
+		}
+	}
+	return nil
+}
+
+// unsolved returns the boards that still accept guesses.
+func (g *Game) unsolved() []*Wordle {
+	boards := make([]*Wordle, 0, len(g.boards))
+	for _, board := range g.boards {
+		if board.status == ONGOING {
+			boards = append(boards, board)
+		}
+	}
+	return boards
+}
+
+func (g *Game) index(board *Wordle) int {
+	for i := range g.boards {
+		if g.boards[i] == board {
+			return i
+		}
+	}
+	return -1
+}
+
+// replay submits words as if they had been guessed, ignoring hard mode so a
+// game recorded without it can always be restored.
+func (g *Game) replay(words []string) error {
+	return g.withoutHardMode(func() error {
+		for _, word := range words {
+			if err := g.guess(word); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// replayFeedback enters words with the feedback patterns entered for them,
+// ignoring hard mode like replay.
+func (g *Game) replayFeedback(words []string, patterns []string) error {
+	if len(patterns) != len(words) {
+		return fmt.Errorf("Error: Expected feedback for %d guesses", len(words))
+	}
+	return g.withoutHardMode(func() error {
+		for i, word := range words {
+			feedback, err := parsePattern(patterns[i], g.length())
+			if err != nil {
+				return err
+			}
+			if err := g.enter(word, feedback); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (g *Game) withoutHardMode(f func() error) error {
+	for _, board := range g.boards {
+		board.hard = false
+	}
+	defer func() {
+		for _, board := range g.boards {
+			board.hard = g.options.Hard
+		}
+	}()
+	return f()
+}
+
+func (g *Game) setHardMode(hard bool) error {
+	if g.attempt > 0 {
+		g.message = "hard mode can only be changed before the first guess"
+		return fmt.Errorf("Error: Game already started")
+	}
+	for _, board := range g.boards {
+		board.setHardMode(hard)
+	}
+	g.options.Hard = hard
+	return nil
+}
+
+// hint returns the first reason why guess is not a possible solution of an
+// unsolved board.
+func (g *Game) hint(guess Guess) string {
+	for _, board := range g.unsolved() {
+		if board.validateFull(guess) {
+			continue
+		}
+		if len(g.boards) > 1 {
+			return fmt.Sprintf("board %d: %s", g.index(board)+1, board.message)
+		}
+		return board.message
+	}
+	return ""
+}
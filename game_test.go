@@ -0,0 +1,167 @@
+package main
+
+import (
+	"testing"
+)
+
+func NewTestGame(solutions ...string) *Game {
+	options := DefaultOptions()
+	options.Boards = len(solutions)
+	options.Guesses = defaultGuesses(len(solutions))
+	game, err := NewGame(options)
+	if err != nil {
+		panic(err)
+	}
+	for i, solution := range solutions {
+		game.boards[i].solution = solution
+	}
+	return game
+}
+
+func TestNewGameDistinctSolutions(t *testing.T) {
+	options := DefaultOptions()
+	options.Boards = 8
+	game, err := NewGame(options)
+	if err != nil {
+		t.Fatalf("Expected game to be created but got %s", err)
+	}
+	seen := make(map[string]bool)
+	for _, board := range game.boards {
+		if seen[board.solution] {
+			t.Errorf("Expected every board to have its own solution but '%s' is repeated", board.solution)
+		}
+		seen[board.solution] = true
+	}
+
+	options.Boards = 3
+	if _, err := NewGame(options); err == nil {
+		t.Errorf("Expected an error for 3 boards")
+	}
+}
+
+func TestNewGameTooFewSolutions(t *testing.T) {
+	en := LANGUAGES[DEFAULT_LANGUAGE]
+	useWordLists(en, 5, []string{"earth", "heart"}, nil)
+	t.Cleanup(func() { useWordLists(en, 5, nil, nil) })
+
+	options := DefaultOptions()
+	options.Boards = 2
+	if _, err := NewGame(options); err != nil {
+		t.Errorf("Expected a game with a solution per board but got %s", err)
+	}
+	options.Boards = 4
+	if _, err := NewGame(options); err == nil {
+		t.Errorf("Expected an error for more boards than solutions")
+	}
+}
+
+func TestNewGameAbsurdle(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = ABSURDLE
+	options.Boards = 2
+	if _, err := NewGame(options); err == nil {
+		t.Errorf("Expected absurdle to require a single board")
+	}
+}
+
+func TestGameGuess(t *testing.T) {
+	game := NewTestGame("earth", "adept")
+	if err := game.guess("earth"); err != nil {
+		t.Fatalf("Expected guess to be successful but got %s", err)
+	}
+	if game.boards[0].status != WIN || game.status != ONGOING {
+		t.Errorf("Expected only the first board to be solved")
+	}
+	if game.boards[1].board[0][0].feedback != YELLOW {
+		t.Errorf("Expected the guess to be applied to the second board")
+	}
+
+	if err := game.guess("adept"); err != nil {
+		t.Fatalf("Expected guess to be successful but got %s", err)
+	}
+	if game.boards[0].attempt != 1 {
+		t.Errorf("Expected solved board to ignore further guesses")
+	}
+	if game.status != WIN {
+		t.Errorf("Expected status to be 'WIN' once every board is solved")
+	}
+}
+
+func TestGameLose(t *testing.T) {
+	game := NewTestGame("earth", "adept")
+	for i := 0; i < game.options.Guesses; i++ {
+		if err := game.guess("earth"); err != nil {
+			t.Fatalf("Expected guess to be successful but got %s", err)
+		}
+	}
+	if game.status != LOSE {
+		t.Errorf("Expected status to be 'LOSE' when a board is unsolved")
+	}
+}
+
+func TestGameGuessHardModeAllOrNothing(t *testing.T) {
+	game := NewTestGame("earth", "pilot")
+	game.setHardMode(true)
+	if err := game.guess("pithy"); err != nil {
+		t.Fatalf("Expected guess to be successful but got %s", err)
+	}
+	// valid for 'earth' but ignores the green 'p' of 'pilot'
+	if err := game.guess("hater"); err == nil {
+		t.Fatalf("Expected guess to be rejected in hard mode")
+	}
+	for i, board := range game.boards {
+		if board.attempt != 1 {
+			t.Errorf("Expected rejected guess not to be applied to board %d", i+1)
+		}
+	}
+}
+
+func TestGameAssist(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = ASSIST
+	options.Boards = 2
+	if _, err := NewGame(options); err == nil {
+		t.Errorf("Expected the assistant to require a single board")
+	}
+
+	options.Boards = 1
+	game, err := NewGame(options)
+	if err != nil {
+		t.Fatalf("Expected game to be created but got %s", err)
+	}
+	if err := game.enter("adept", []Feedback{YELLOW, GREY, YELLOW, GREY, YELLOW}); err != nil {
+		t.Fatalf("Expected feedback to be entered but got %s", err)
+	}
+	if game.attempt != 1 || game.history[0] != "adept" {
+		t.Errorf("Expected the guess to be recorded")
+	}
+	if err := game.enter("xxxxx", []Feedback{GREY, GREY, GREY, GREY, GREY}); err == nil {
+		t.Errorf("Expected unknown words to be rejected")
+	}
+}
+
+func TestGameTimeout(t *testing.T) {
+	game := NewTestGame("earth", "adept")
+	if err := game.guess("earth"); err != nil {
+		t.Fatalf("Expected guess to be successful but got %s", err)
+	}
+	game.timeout()
+	if game.status != LOSE || game.boards[0].status != WIN || game.boards[1].status != LOSE {
+		t.Errorf("Expected only the unsolved board to be lost")
+	}
+	if err := game.guess("adept"); err == nil {
+		t.Errorf("Expected no guesses after the timeout")
+	}
+
+	options := DefaultOptions()
+	options.Mode = COUNTDOWN
+	if _, err := NewGame(options); err == nil {
+		t.Errorf("Expected an error for a countdown without a time limit")
+	}
+	options.Limit = COUNTDOWN_LIMIT
+	options.Solution = "earth"
+	options.Boards = 2
+	if _, err := NewGame(options); err == nil {
+		t.Errorf("Expected a countdown with a solution to require a single board")
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	MULTIPLAYER_ADDR    = ":7777"
+	MULTIPLAYER_PLAYERS = 2
+)
+
+// netMessage is a line of the multiplayer protocol, JSON encoded. Players
+// send "join" once connected and "guess" for every guess. The host answers
+// with "welcome", starts the game with "start" once everyone joined, replies
+// to guesses with "feedback" or "error", tells the others about them with
+// "progress" and ends the game with "over".
+type netMessage struct {
+	Type     string      `json:"type"`
+	Name     string      `json:"name,omitempty"`
+	Player   int         `json:"player"`
+	Players  []string    `json:"players,omitempty"`
+	Options  *Options    `json:"options,omitempty"`
+	Guess    string      `json:"guess,omitempty"`
+	Pattern  string      `json:"pattern,omitempty"`
+	Status   string      `json:"status,omitempty"`
+	Message  string      `json:"message,omitempty"`
+	Winner   int         `json:"winner"`
+	Solution string      `json:"solution,omitempty"`
+	Results  []netResult `json:"results,omitempty"`
+}
+
+// netResult is how a player did, sent once every player is done.
+type netResult struct {
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+	Guesses int     `json:"guesses"`
+	Seconds float64 `json:"seconds"`
+}
+
+type hostPlayer struct {
+	index    int
+	name     string
+	conn     net.Conn
+	reader   *bufio.Reader
+	encoder  *json.Encoder
+	game     *Game
+	finished time.Duration
+	left     bool
+}
+
+// Host runs a multiplayer game. Every player guesses the same solution on
+// their own board, the host scores all guesses so players never learn the
+// solution before the game is over. The winner needs the fewest guesses,
+// ties go to whoever was faster.
+type Host struct {
+	options  Options
+	solution string
+	listener net.Listener
+	count    int
+	mu       sync.Mutex
+	players  []*hostPlayer
+	start    time.Time
+	done     chan struct{}
+	over     netMessage
+}
+
+// NewHost prepares a game for count players who connect to listener. A
+// solution is drawn for options, which must be a single board classic game.
+func NewHost(listener net.Listener, options Options, count int) (*Host, error) {
+	if count < 1 {
+		return nil, fmt.Errorf("Error: At least one player is needed")
+	}
+	options.Mode = CLASSIC
+	options.Boards = 1
+	options.Solution = ""
+	game, err := NewGame(options)
+	if err != nil {
+		return nil, err
+	}
+	return &Host{
+		options:  options,
+		solution: game.boards[0].solution,
+		listener: listener,
+		count:    count,
+		players:  make([]*hostPlayer, 0, count),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// run waits for every player to join, plays the game and returns the final
+// message once every player is done.
+func (h *Host) run() (netMessage, error) {
+	for len(h.players) < h.count {
+		conn, err := h.listener.Accept()
+		if err != nil {
+			return netMessage{}, err
+		}
+		if err := h.join(conn); err != nil {
+			conn.Close()
+		}
+	}
+
+	h.mu.Lock()
+	h.start = time.Now()
+	names := make([]string, len(h.players))
+	for i, player := range h.players {
+		names[i] = player.name
+	}
+	options := h.options
+	for _, player := range h.players {
+		player.encoder.Encode(netMessage{Type: "start", Player: player.index, Players: names, Options: &options})
+	}
+	h.mu.Unlock()
+
+	for _, player := range h.players {
+		go h.listen(player)
+	}
+	<-h.done
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, player := range h.players {
+		player.conn.Close()
+	}
+	return h.over, nil
+}
+
+// join waits for the join message of a new connection.
+func (h *Host) join(conn net.Conn) error {
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return err
+	}
+	conn.SetReadDeadline(time.Time{})
+	var message netMessage
+	if err := json.Unmarshal(line, &message); err != nil || message.Type != "join" {
+		return fmt.Errorf("Error: Expected a join message")
+	}
+
+	options := h.options
+	options.Mode = CHALLENGE
+	options.Solution = h.solution
+	game, err := NewGame(options)
+	if err != nil {
+		return err
+	}
+	name := strings.TrimSpace(message.Name)
+	if name == "" {
+		name = fmt.Sprintf("player %d", len(h.players)+1)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	player := &hostPlayer{
+		index:   len(h.players),
+		name:    name,
+		conn:    conn,
+		reader:  reader,
+		encoder: json.NewEncoder(conn),
+		game:    game,
+	}
+	h.players = append(h.players, player)
+	return player.encoder.Encode(netMessage{Type: "welcome", Player: player.index, Name: name})
+}
+
+// listen reads the guesses of a player until they disconnect.
+func (h *Host) listen(player *hostPlayer) {
+	scanner := bufio.NewScanner(player.reader)
+	for scanner.Scan() {
+		var message netMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil || message.Type != "guess" {
+			continue
+		}
+		h.guess(player, message.Guess)
+	}
+	h.leave(player)
+}
+
+func (h *Host) guess(player *hostPlayer, word string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	game := player.game
+	if err := game.guess(strings.ToLower(word)); err != nil {
+		player.encoder.Encode(netMessage{Type: "error", Player: player.index, Guess: word, Message: game.message})
+		return
+	}
+
+	board := game.boards[0]
+	pattern := board.board[board.attempt-1].pattern()
+	status := statusNames[game.status]
+	player.encoder.Encode(netMessage{Type: "feedback", Player: player.index, Guess: game.history[game.attempt-1], Pattern: pattern, Status: status})
+	for _, other := range h.players {
+		if other != player && !other.left {
+			// opponents only see the colours
+			other.encoder.Encode(netMessage{Type: "progress", Player: player.index, Pattern: pattern, Status: status})
+		}
+	}
+	if game.status != ONGOING {
+		player.finished = time.Since(h.start)
+		h.finish()
+	}
+}
+
+func (h *Host) leave(player *hostPlayer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if player.left {
+		return
+	}
+	player.left = true
+	if player.game.status == ONGOING {
+		player.game.status = LOSE
+		player.finished = time.Since(h.start)
+		for _, other := range h.players {
+			if !other.left {
+				other.encoder.Encode(netMessage{Type: "progress", Player: player.index, Status: "left"})
+			}
+		}
+	}
+	h.finish()
+}
+
+// finish ends the game once every player is done, the lock has to be held.
+func (h *Host) finish() {
+	select {
+	case <-h.done:
+		return
+	default:
+	}
+	for _, player := range h.players {
+		if player.game.status == ONGOING {
+			return
+		}
+	}
+
+	h.over = netMessage{Type: "over", Winner: hostWinner(h.players), Solution: h.solution, Results: make([]netResult, len(h.players))}
+	for i, player := range h.players {
+		h.over.Results[i] = netResult{
+			Name:    player.name,
+			Status:  statusNames[player.game.status],
+			Guesses: player.game.attempt,
+			Seconds: player.finished.Seconds(),
+		}
+	}
+	for _, player := range h.players {
+		if !player.left {
+			player.encoder.Encode(h.over)
+		}
+	}
+	close(h.done)
+}
+
+// hostWinner returns the index of the player who solved the puzzle in the
+// fewest guesses, the faster one on a tie, or -1 if nobody solved it.
+func hostWinner(players []*hostPlayer) int {
+	winners := make([]*hostPlayer, 0, len(players))
+	for _, player := range players {
+		if player.game.status == WIN {
+			winners = append(winners, player)
+		}
+	}
+	if len(winners) == 0 {
+		return -1
+	}
+	sort.SliceStable(winners, func(i, j int) bool {
+		if winners[i].game.attempt != winners[j].game.attempt {
+			return winners[i].game.attempt < winners[j].game.attempt
+		}
+		return winners[i].finished < winners[j].finished
+	})
+	return winners[0].index
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// startHost runs a host for count players on a loopback port with "earth"
+// as the solution.
+func startHost(t *testing.T, options Options, count int) (*Host, chan netMessage) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	host, err := NewHost(listener, options, count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	host.solution = "earth"
+	over := make(chan netMessage, 1)
+	go func() {
+		message, _ := host.run()
+		over <- message
+	}()
+	return host, over
+}
+
+func joinHost(t *testing.T, host *Host, name string) *Client {
+	client, err := Dial(host.listener.Addr().String(), name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	if message := expectMessage(t, client, "welcome"); message.Name != name {
+		t.Errorf("Expected to be welcomed as %s but got %+v", name, message)
+	}
+	return client
+}
+
+func expectMessage(t *testing.T, client *Client, kind string) netMessage {
+	t.Helper()
+	select {
+	case message, ok := <-client.messages:
+		if !ok {
+			t.Fatalf("Expected a %s message but the connection was closed", kind)
+		}
+		if message.Type != kind {
+			t.Fatalf("Expected a %s message but got %+v", kind, message)
+		}
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected a %s message but got none", kind)
+	}
+	return netMessage{}
+}
+
+func TestHostGame(t *testing.T) {
+	host, over := startHost(t, DefaultOptions(), 2)
+	alice := joinHost(t, host, "alice")
+	bob := joinHost(t, host, "bob")
+
+	start := expectMessage(t, alice, "start")
+	if start.Player != 0 || len(start.Players) != 2 || start.Players[1] != "bob" {
+		t.Errorf("Expected the players in the start message but got %+v", start)
+	}
+	if start.Options == nil || start.Options.Length != 5 || start.Options.Solution != "" {
+		t.Errorf("Expected the options without the solution but got %+v", start.Options)
+	}
+	expectMessage(t, bob, "start")
+
+	alice.guess("xxxxx")
+	if message := expectMessage(t, alice, "error"); message.Message != "'xxxxx' is not a valid word" {
+		t.Errorf("Expected the invalid word to be rejected but got %+v", message)
+	}
+
+	alice.guess("adept")
+	if message := expectMessage(t, alice, "feedback"); message.Guess != "adept" || message.Pattern != "ybyby" || message.Status != "ongoing" {
+		t.Errorf("Expected the feedback of the guess but got %+v", message)
+	}
+	progress := expectMessage(t, bob, "progress")
+	if progress.Player != 0 || progress.Pattern != "ybyby" || progress.Guess != "" {
+		t.Errorf("Expected the colours of the guess without its letters but got %+v", progress)
+	}
+
+	bob.guess("earth")
+	if message := expectMessage(t, bob, "feedback"); message.Status != "win" {
+		t.Errorf("Expected bob to win but got %+v", message)
+	}
+	expectMessage(t, alice, "progress")
+	alice.guess("earth")
+	expectMessage(t, alice, "feedback")
+	expectMessage(t, bob, "progress")
+
+	for _, client := range []*Client{alice, bob} {
+		message := expectMessage(t, client, "over")
+		if message.Winner != 1 || message.Solution != "earth" || len(message.Results) != 2 {
+			t.Errorf("Expected bob to win with fewer guesses but got %+v", message)
+		}
+		if message.Results[0].Guesses != 2 || message.Results[1].Guesses != 1 {
+			t.Errorf("Expected the guesses of every player but got %+v", message.Results)
+		}
+	}
+	if message := <-over; message.Winner != 1 {
+		t.Errorf("Expected the host to return the winner but got %+v", message)
+	}
+}
+
+func TestHostLeave(t *testing.T) {
+	host, over := startHost(t, DefaultOptions(), 2)
+	alice := joinHost(t, host, "alice")
+	bob := joinHost(t, host, "bob")
+	expectMessage(t, alice, "start")
+	expectMessage(t, bob, "start")
+
+	bob.Close()
+	if message := expectMessage(t, alice, "progress"); message.Player != 1 || message.Status != "left" {
+		t.Errorf("Expected bob to have left but got %+v", message)
+	}
+	alice.guess("adept")
+	expectMessage(t, alice, "feedback")
+	alice.guess("earth")
+	expectMessage(t, alice, "feedback")
+	if message := expectMessage(t, alice, "over"); message.Winner != 0 || message.Results[1].Status != "lose" {
+		t.Errorf("Expected alice to win after bob left but got %+v", message)
+	}
+	<-over
+}
+
+func TestHostWinner(t *testing.T) {
+	player := func(index int, status GameStatus, attempt int, finished time.Duration) *hostPlayer {
+		return &hostPlayer{index: index, game: &Game{status: status, attempt: attempt}, finished: finished}
+	}
+	tests := []struct {
+		players  []*hostPlayer
+		expected int
+	}{
+		{[]*hostPlayer{player(0, WIN, 4, time.Second), player(1, WIN, 3, time.Minute)}, 1},
+		{[]*hostPlayer{player(0, WIN, 3, time.Minute), player(1, WIN, 3, time.Second)}, 1},
+		{[]*hostPlayer{player(0, WIN, 3, time.Second), player(1, WIN, 3, time.Second)}, 0},
+		{[]*hostPlayer{player(0, LOSE, 2, time.Second), player(1, WIN, 6, time.Minute)}, 1},
+		{[]*hostPlayer{player(0, LOSE, 6, time.Second), player(1, LOSE, 6, time.Second)}, -1},
+	}
+	for i, test := range tests {
+		if winner := hostWinner(test.players); winner != test.expected {
+			t.Errorf("%d: Expected %d to win but got %d", i, test.expected, winner)
+		}
+	}
+}
+
+func TestNewHost(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = ABSURDLE
+	options.Boards = 2
+	host, err := NewHost(nil, options, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if host.options.Mode != CLASSIC || host.options.Boards != 1 || host.solution == "" {
+		t.Errorf("Expected a single board classic game but got %+v", host.options)
+	}
+	if _, err := NewHost(nil, options, 0); err == nil {
+		t.Error("Expected a host without players to fail")
+	}
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+// Client is the connection of a player to a host.
+type Client struct {
+	conn     net.Conn
+	encoder  *json.Encoder
+	messages chan netMessage
+}
+
+// Dial connects to the host at addr and joins as name. Messages of the host
+// are delivered on Client.messages, which is closed when the connection is.
+func Dial(addr string, name string) (*Client, error) {
+	conn, err := net.DialTimeout("tcp", addr, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	client := &Client{
+		conn:     conn,
+		encoder:  json.NewEncoder(conn),
+		messages: make(chan netMessage, 16),
+	}
+	if err := client.encoder.Encode(netMessage{Type: "join", Name: name}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go client.listen()
+	return client, nil
+}
+
+func (c *Client) listen() {
+	defer close(c.messages)
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		var message netMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err == nil {
+			c.messages <- message
+		}
+	}
+}
+
+func (c *Client) guess(word string) error {
+	return c.encoder.Encode(netMessage{Type: "guess", Guess: word})
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// netMsg delivers a message of the host to the Bubble Tea program.
+type netMsg netMessage
+
+// netClosedMsg tells that the connection to the host was lost.
+type netClosedMsg struct{}
+
+// receive waits for the next message of the host.
+func (c *Client) receive() tea.Cmd {
+	return func() tea.Msg {
+		message, ok := <-c.messages
+		if !ok {
+			return netClosedMsg{}
+		}
+		return netMsg(message)
+	}
+}
+
+// netModel plays a multiplayer game. The own board is an assist mode game
+// that is fed the feedback of the host, the boards of the opponents only
+// hold colours.
+type netModel struct {
+	model
+	client    *Client
+	player    int
+	players   []string
+	opponents [][]string // player -> patterns
+	status    []string   // player -> status
+	started   bool
+	sent      bool // a guess is waiting for the host
+	over      *netMessage
+}
+
+func NewNetModel(client *Client) (netModel, error) {
+	options := DefaultOptions()
+	options.Mode = ASSIST
+	m, err := NewModel(options)
+	if err != nil {
+		return netModel{}, err
+	}
+	return netModel{model: m, client: client}, nil
+}
+
+func (m netModel) Init() tea.Cmd {
+	return m.client.receive()
+}
+
+func (m netModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case netMsg:
+		m.handleMessage(netMessage(msg))
+		return m, m.client.receive()
+	case netClosedMsg:
+		if m.over == nil {
+			m.warning = "lost the connection to the host"
+		}
+	case tea.KeyMsg:
+		m.warning = ""
+		switch msg.String() {
+		case "ctrl+c":
+			m.client.Close()
+			return m, tea.Quit
+		case tea.KeyBackspace.String():
+			if m.started && !m.sent {
+				m.handleKeyBackspace(msg)
+			}
+		case tea.KeyEnter.String():
+			m.handleNetEnter()
+		default:
+			if m.over != nil {
+				return m, tea.Quit
+			}
+			if !m.started || m.sent || m.game.status != ONGOING {
+				return m, nil
+			}
+			if m.isLetter(msg) {
+				m.handleKeyAlphabet(msg)
+			}
+		}
+	}
+	return m, nil
+}
+
+// handleNetEnter sends the typed word to the host. Words that can't be
+// guessed are caught right away.
+func (m *netModel) handleNetEnter() {
+	if !m.started || m.sent || m.game.status != ONGOING || m.cursor != m.game.length()-1 {
+		return
+	}
+	word := ""
+	for i := range m.inputs[m.game.attempt] {
+		word += m.inputs[m.game.attempt][i].Value()
+	}
+	word = strings.ToLower(word)
+	if err := m.game.check(word); err != nil {
+		m.warning = m.game.message
+		return
+	}
+	if err := m.client.guess(word); err != nil {
+		m.warning = err.Error()
+		return
+	}
+	m.sent = true
+}
+
+func (m *netModel) handleMessage(message netMessage) {
+	switch message.Type {
+	case "welcome":
+		m.player = message.Player
+	case "start":
+		if message.Options == nil {
+			return
+		}
+		options := *message.Options
+		options.Mode = ASSIST
+		game, err := NewGame(options)
+		if err != nil {
+			m.warning = err.Error()
+			return
+		}
+		m.game = game
+		m.inputs = newInputs(game)
+		m.cursor = 0
+		m.player = message.Player
+		m.players = message.Players
+		m.opponents = make([][]string, len(message.Players))
+		m.status = make([]string, len(message.Players))
+		for i := range m.status {
+			m.status[i] = statusNames[ONGOING]
+		}
+		m.started = true
+	case "feedback":
+		m.sent = false
+		feedback, err := parsePattern(message.Pattern, m.game.length())
+		if err == nil {
+			err = m.game.enter(message.Guess, feedback)
+		}
+		if err != nil {
+			m.warning = err.Error()
+			return
+		}
+		m.cursor = 0
+		m.status[m.player] = message.Status
+	case "progress":
+		if message.Player < 0 || message.Player >= len(m.opponents) {
+			return
+		}
+		if message.Pattern != "" {
+			m.opponents[message.Player] = append(m.opponents[message.Player], message.Pattern)
+		}
+		m.status[message.Player] = message.Status
+	case "error":
+		m.sent = false
+		m.warning = message.Message
+	case "over":
+		m.over = &message
+	}
+}
+
+func (m netModel) View() string {
+	if m.width == 0 {
+		return "loading..."
+	}
+	return lipgloss.Place(m.width,
+		m.height,
+		lipgloss.Center,
+		lipgloss.Center,
+		lipgloss.JoinHorizontal(
+			lipgloss.Bottom,
+			m.NetBoardView(),
+			lipgloss.JoinVertical(
+				lipgloss.Left,
+				m.AlphabetView(),
+				m.OpponentsView(),
+				m.ResultView(),
+				m.HintView(),
+			),
+		),
+	)
+}
+
+func (m netModel) NetBoardView() string {
+	title := "WAITING FOR PLAYERS"
+	if m.started {
+		title = strings.ToUpper(m.players[m.player])
+	}
+	if m.game.status == WIN {
+		title = "SOLVED"
+	} else if m.game.status == LOSE {
+		title = "OUT OF GUESSES"
+	}
+	return lipgloss.NewStyle().MarginRight(2).Render(lipgloss.JoinVertical(
+		lipgloss.Center,
+		titleStyle.Render(title),
+		m.boardView(m.game.boards[0]),
+	))
+}
+
+// OpponentsView shows the colours of the other boards, without letters.
+func (m netModel) OpponentsView() string {
+	boards := make([]string, 0, len(m.players))
+	for i, name := range m.players {
+		if i == m.player {
+			continue
+		}
+		rows := make([]string, 0, m.game.options.Guesses)
+		for row := 0; row < m.game.options.Guesses; row++ {
+			feedback := make([]Feedback, m.game.length())
+			if row < len(m.opponents[i]) {
+				if parsed, err := parsePattern(m.opponents[i][row], m.game.length()); err == nil {
+					feedback = parsed
+				}
+			}
+			tiles := make([]string, m.game.length())
+			for j := range tiles {
+				tiles[j] = tileStyle(feedback[j], true).Render(" ")
+			}
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, tiles...))
+		}
+		label := name
+		if m.status[i] != statusNames[ONGOING] {
+			label += " (" + m.status[i] + ")"
+		}
+		boards = append(boards, lipgloss.NewStyle().MarginRight(2).MarginBottom(1).Render(lipgloss.JoinVertical(
+			lipgloss.Left,
+			helpTextStyle.Render(label),
+			lipgloss.JoinVertical(lipgloss.Left, rows...),
+		)))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, boards...)
+}
+
+func (m netModel) ResultView() string {
+	if m.over == nil {
+		return ""
+	}
+	var s strings.Builder
+	switch {
+	case m.over.Winner == m.player:
+		s.WriteString("You win!\n")
+	case m.over.Winner >= 0:
+		s.WriteString(fmt.Sprintf("%s wins!\n", m.over.Results[m.over.Winner].Name))
+	default:
+		s.WriteString("Nobody solved it.\n")
+	}
+	s.WriteString(fmt.Sprintf("The word was %s\n", strings.ToUpper(m.over.Solution)))
+	for _, result := range m.over.Results {
+		s.WriteString(fmt.Sprintf("  %-12s %-5s %d guesses %6.1fs\n", result.Name, result.Status, result.Guesses, result.Seconds))
+	}
+	return lipgloss.NewStyle().MarginBottom(1).Render(s.String())
+}
+
+func playerName() string {
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return ""
+}
+
+// playNet runs the multiplayer TUI for client.
+func playNet(client *Client) error {
+	m, err := NewNetModel(client)
+	if err != nil {
+		return err
+	}
+	_, err = tea.NewProgram(m).Run()
+	return err
+}
+
+func runHost(args []string) error {
+	options := DefaultOptions()
+	flags := flag.NewFlagSet("host", flag.ContinueOnError)
+	addr := flags.String("addr", MULTIPLAYER_ADDR, "address to listen on")
+	players := flags.Int("players", MULTIPLAYER_PLAYERS, "number of players, including you")
+	name := flags.String("name", playerName(), "your name")
+	flags.IntVar(&options.Length, "length", DEFAULT_WORD_LENGTH, fmt.Sprintf("number of letters per word (%d-%d)", MIN_WORD_LENGTH, MAX_WORD_LENGTH))
+	flags.IntVar(&options.Guesses, "guesses", DEFAULT_GUESSES, fmt.Sprintf("number of allowed guesses (%d-%d)", MIN_GUESSES, MAX_GUESSES))
+	flags.BoolVar(&options.Hard, "hard", false, "everyone plays in hard mode")
+	flags.StringVar(&options.Lang, "lang", DEFAULT_LANGUAGE, fmt.Sprintf("language of the words, one of %s", strings.Join(languageCodes(), ", ")))
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s host [flags]\n", APP_NAME)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	host, err := NewHost(listener, options, *players)
+	if err != nil {
+		return err
+	}
+	go host.run()
+
+	client, err := Dial(listener.Addr().String(), *name)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return playNet(client)
+}
+
+func runJoin(args []string) error {
+	flags := flag.NewFlagSet("join", flag.ContinueOnError)
+	name := flags.String("name", playerName(), "your name")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s join [flags] HOST:PORT\n", APP_NAME)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("Error: Expected the address of the host")
+	}
+
+	client, err := Dial(flags.Arg(0), *name)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return playNet(client)
+}
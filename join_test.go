@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNetModel(t *testing.T) {
+	host, _ := startHost(t, DefaultOptions(), 2)
+	client := joinHost(t, host, "alice")
+	bob := joinHost(t, host, "bob")
+	m, err := NewNetModel(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var model tea.Model = m
+	update := func(msg tea.Msg) {
+		model, _ = model.Update(msg)
+	}
+	update(netMsg(expectMessage(t, client, "start")))
+	expectMessage(t, bob, "start")
+	m = model.(netModel)
+	if !m.started || m.player != 0 || m.game.options.Mode != ASSIST {
+		t.Fatalf("Expected the game to start but got %+v", m.game.options)
+	}
+
+	for _, msg := range runes("adept") {
+		update(msg)
+	}
+	update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m = model.(netModel); !m.sent {
+		t.Fatalf("Expected the guess to be sent but got warning '%s'", m.warning)
+	}
+	update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	update(netMsg(expectMessage(t, client, "feedback")))
+	m = model.(netModel)
+	if m.sent || m.game.attempt != 1 || m.game.boards[0].board[0].pattern() != "ybyby" {
+		t.Errorf("Expected the feedback of the host on the board but got %+v", m.game.history)
+	}
+	if m.inputs[1][0].Value() != "" {
+		t.Error("Expected typing to wait for the feedback")
+	}
+
+	update(netMsg{Type: "progress", Player: 1, Pattern: "bbgby", Status: "ongoing"})
+	update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = model.(netModel)
+	if len(m.opponents[1]) != 1 || m.opponents[1][0] != "bbgby" {
+		t.Errorf("Expected the colours of bob but got %v", m.opponents)
+	}
+	if view := m.View(); !strings.Contains(view, "bob") || strings.Contains(view, "EARTH") {
+		t.Errorf("Expected the board of bob without letters but got\n%s", view)
+	}
+
+	update(netMsg{Type: "over", Winner: 1, Solution: "earth", Results: []netResult{{Name: "alice", Status: "lose"}, {Name: "bob", Status: "win", Guesses: 2}}})
+	if view := model.(netModel).View(); !strings.Contains(view, "bob wins!") || !strings.Contains(view, "EARTH") {
+		t.Errorf("Expected the winner in the view but got\n%s", view)
+	}
+}
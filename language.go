@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const DEFAULT_LANGUAGE = "en"
+
+// Language defines the letters words are made of and how they are laid out
+// on the keyboard. The alphabet is made of the keys of the keyboard.
+type Language struct {
+	code     string
+	name     string
+	keyboard [][]rune
+	alphabet []rune       // sorted
+	index    map[rune]int // letter -> position in the alphabet
+	lists    string       // directory of the word lists in words/, empty for the English ones
+}
+
+func NewLanguage(code string, name string, lists string, keyboard ...string) *Language {
+	lang := &Language{
+		code:     code,
+		name:     name,
+		keyboard: make([][]rune, len(keyboard)),
+		index:    make(map[rune]int),
+		lists:    lists,
+	}
+	for i, row := range keyboard {
+		lang.keyboard[i] = []rune(row)
+		lang.alphabet = append(lang.alphabet, []rune(row)...)
+	}
+	sort.Slice(lang.alphabet, func(i, j int) bool {
+		return lang.alphabet[i] < lang.alphabet[j]
+	})
+	for i, char := range lang.alphabet {
+		lang.index[char] = i
+	}
+	return lang
+}
+
+var LANGUAGES = map[string]*Language{
+	"en": NewLanguage("en", "English", "", "qwertyuiop", "asdfghjkl", "zxcvbnm"),
+	"de": NewLanguage("de", "Deutsch", "de", "qwertzuiopü", "asdfghjklöä", "yxcvbnmß"),
+}
+
+// language returns the language with the given code, the empty code is
+// English.
+func language(code string) (*Language, error) {
+	if code == "" {
+		code = DEFAULT_LANGUAGE
+	}
+	lang, ok := LANGUAGES[code]
+	if !ok {
+		return nil, fmt.Errorf("Error: Unknown language '%s', expected one of %s", code, strings.Join(languageCodes(), ", "))
+	}
+	return lang, nil
+}
+
+func languageCodes() []string {
+	codes := make([]string, 0, len(LANGUAGES))
+	for code := range LANGUAGES {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// idx returns the position of char in the alphabet, or -1 if it is not part
+// of it.
+func (l *Language) idx(char rune) int {
+	if i, ok := l.index[char]; ok {
+		return i
+	}
+	return -1
+}
+
+func (l *Language) contains(char rune) bool {
+	_, ok := l.index[char]
+	return ok
+}
+
+// valid reports whether word only uses letters of the alphabet.
+func (l *Language) valid(word string) bool {
+	for _, char := range word {
+		if !l.contains(char) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestLanguage(t *testing.T) {
+	if lang, err := language(""); err != nil || lang.code != DEFAULT_LANGUAGE {
+		t.Errorf("Expected the empty code to be English but got %v (%v)", lang, err)
+	}
+	if _, err := language("xx"); err == nil || !strings.Contains(err.Error(), "de, en") {
+		t.Errorf("Expected an error listing the languages but got %v", err)
+	}
+
+	en, de := LANGUAGES["en"], LANGUAGES["de"]
+	if len(en.alphabet) != 26 || en.idx('a') != 0 || en.idx('z') != 25 || en.idx('ä') != -1 {
+		t.Errorf("Expected the English alphabet to be a-z")
+	}
+	if len(de.alphabet) != 30 || !de.valid("größe") || en.valid("größe") {
+		t.Errorf("Expected the German alphabet to have umlauts and ß")
+	}
+	for i := 1; i < len(de.alphabet); i++ {
+		if de.alphabet[i-1] >= de.alphabet[i] || de.idx(de.alphabet[i]) != i {
+			t.Fatalf("Expected the alphabet to be sorted and indexed")
+		}
+	}
+}
+
+func TestGermanDictionary(t *testing.T) {
+	de := LANGUAGES["de"]
+	solutions, guesses, err := loadTries(de, 5)
+	if err != nil {
+		t.Fatalf("Expected the German dictionary but got %s", err)
+	}
+	for _, word := range solutions.words() {
+		if message := checkWord(word, de, 5); message != "" || !guesses.findWord(word) {
+			t.Errorf("Expected '%s' to be a playable solution: %s", word, message)
+		}
+	}
+	if !solutions.findWord("größe") || solutions.findWord("schön") || !guesses.findWord("schön") {
+		t.Errorf("Expected solutions and guesses to be kept apart")
+	}
+	if _, _, err := loadTries(de, 7); err == nil || !strings.Contains(err.Error(), "Deutsch") {
+		t.Errorf("Expected an error for a missing German dictionary but got %v", err)
+	}
+	useWordLists(de, 7, []string{"schnell"}, nil)
+	t.Cleanup(func() { useWordLists(de, 7, nil, nil) })
+	if _, _, err := loadTries(de, 7); err == nil || !strings.Contains(err.Error(), "--guess-list is missing") {
+		t.Errorf("Expected an error naming the missing guess list but got %v", err)
+	}
+	useWordLists(de, 7, nil, []string{"schnell"})
+	if _, _, err := loadTries(de, 7); err == nil || !strings.Contains(err.Error(), "--solutions is missing") {
+		t.Errorf("Expected an error naming the missing solutions but got %v", err)
+	}
+	useWordLists(de, 7, []string{"schnell"}, []string{"schnell"})
+	if solutions, _, err := loadTries(de, 7); err != nil || !solutions.findWord("schnell") {
+		t.Errorf("Expected both custom lists to replace the dictionary but got %v", err)
+	}
+}
+
+func TestGermanGame(t *testing.T) {
+	options := DefaultOptions()
+	options.Lang = "de"
+	wordle, err := NewWordle(options)
+	if err != nil {
+		t.Fatalf("Expected a German game but got %s", err)
+	}
+	wordle.solution = "größe"
+
+	if _, err := NewGuess("größe", LANGUAGES["en"], 5); err == nil {
+		t.Errorf("Expected umlauts to be rejected in English")
+	}
+	if err := wordle.guess("earth"); err == nil {
+		t.Errorf("Expected an English word to be rejected")
+	}
+	if err := wordle.guess("grüße"); err != nil {
+		t.Fatalf("Expected 'grüße' to be guessed but got %s", err)
+	}
+	if pattern := wordle.board[0].pattern(); pattern != "ggbgg" {
+		t.Errorf("Expected feedback ggbgg but got %s", pattern)
+	}
+	if wordle.letterFeedback(wordle.lang.idx('ü')) != GREY || wordle.letterFeedback(wordle.lang.idx('ß')) != GREEN {
+		t.Errorf("Expected the keyboard feedback of umlauts and ß")
+	}
+	if words := wordle.consistentWords(); !reflect.DeepEqual(words, []string{"große", "größe"}) {
+		t.Errorf("Expected 'große' and 'größe' to be left but got %v", words)
+	}
+
+	for _, pair := range [][2]string{{"größe", "große"}, {"flöße", "klöße"}, {"earth", "hände"}} {
+		if feedbackPattern(pair[0], pair[1]) != encodeFeedback(score(pair[0], pair[1])) {
+			t.Errorf("Expected feedbackPattern to match score for %v", pair)
+		}
+	}
+}
+
+func TestGermanKeyboard(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Lang = "de"
+	m := NewTestModel(t, options)
+	m.game.boards[0].solution = "größe"
+	if view := m.AlphabetView(); !strings.Contains(view, "Ö") || !strings.Contains(view, "ß") {
+		t.Errorf("Expected the German keyboard to be shown")
+	}
+
+	m = sendKeys(m, runes("grüße")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.game.attempt != 1 || m.game.boards[0].board[0].word() != "grüße" {
+		t.Errorf("Expected umlauts to be typed but got '%s'", m.game.message)
+	}
+}
+
+func TestGermanInputs(t *testing.T) {
+	options := DefaultOptions()
+	options.Lang = "de"
+	game, _ := NewGame(options)
+	game.boards[0].solution = "größe"
+	if err := game.guess("grüße"); err != nil {
+		t.Fatalf("Expected 'grüße' to be guessed but got %s", err)
+	}
+	inputs := newInputs(game)
+	word := ""
+	for _, input := range inputs[0] {
+		word += input.Value()
+	}
+	if word != "grüße" || inputs[0][2].Value() != "ü" {
+		t.Errorf("Expected a letter per input for a restored guess but got '%s'", word)
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/textinput"
@@ -12,7 +15,7 @@ import (
 )
 
 type model struct {
-	wordle      *Wordle
+	game        *Game
 	width       int
 	height      int
 	inputs      []WordInput
@@ -20,31 +23,100 @@ type model struct {
 	help        bool
 	hints       bool
 	suggestions bool
+	suggested   suggestionsMsg
+	pending     suggestionsMsg
+	solver      Solver
+	remaining   Remaining
+	colours     []Feedback // assist only, feedback being entered
+	tile        int
+	timer       Timer
+	options     Options
 	hint        string
+	warning     string
+	prompting   bool
+	prompt      textinput.Model
+	stats       bool
+	statistics  *Statistics
+	shareStyle  ShareStyle
+	share       string
 }
 
-func NewModel() model {
-	inputs := make([]WordInput, MAX_GUESSES+1)
-	for i := range inputs {
-		inputs[i] = NewWordInput()
+func NewModel(options Options) (model, error) {
+	game, err := startGame(options)
+	if err != nil {
+		return model{}, err
+	}
+	statistics, err := loadStatistics()
+	if err != nil {
+		return model{}, err
 	}
+	solver, _ := NewSolver(SOLVERS[0], 0)
 	return model{
-		wordle:      NewWordle(),
+		game:        game,
 		width:       0,
 		height:      0,
-		inputs:      inputs,
+		inputs:      newInputs(game),
 		cursor:      0,
 		help:        false,
 		hints:       false,
 		hint:        "",
 		suggestions: false,
+		solver:      solver,
+		remaining:   NewRemaining(),
+		timer:       NewTimer(options),
+		options:     options,
+		warning:     "",
+		prompting:   false,
+		prompt:      NewChallengePrompt(),
+		stats:       false,
+		statistics:  statistics,
+		shareStyle:  EMOJI,
+		share:       "",
+	}, nil
+}
+
+func NewChallengePrompt() textinput.Model {
+	prompt := textinput.New()
+	prompt.Prompt = "Challenge: "
+	prompt.Placeholder = "code"
+	prompt.CharLimit = 32
+	return prompt
+}
+
+// startGame creates a game for options. Progress already made on a daily
+// puzzle is restored.
+func startGame(options Options) (*Game, error) {
+	game, err := NewGame(options)
+	if err != nil {
+		return nil, err
+	}
+	if options.Mode == DAILY {
+		if err := restoreDaily(game); err != nil {
+			return nil, err
+		}
+	}
+	return game, nil
+}
+
+// newInputs creates the input rows for game, filled with the guesses made so
+// far.
+func newInputs(game *Game) []WordInput {
+	inputs := make([]WordInput, game.options.Guesses)
+	for i := range inputs {
+		inputs[i] = NewWordInput(game.options.Length)
 	}
+	for i, word := range game.history {
+		for j, char := range []rune(word) {
+			inputs[i][j].SetValue(string(char))
+		}
+	}
+	return inputs
 }
 
 type WordInput []textinput.Model
 
-func NewWordInput() WordInput {
-	fields := make([]textinput.Model, GUESS_LENGTH)
+func NewWordInput(length int) WordInput {
+	fields := make([]textinput.Model, length)
 	cursor := cursor.New()
 	cursor.SetMode(2)
 	for i := range fields {
@@ -70,12 +142,13 @@ const (
 var (
 	defaultInputStyle = lipgloss.NewStyle().
 				Padding(1, 1).Background(colorBlack).Foreground(colorWhite)
-	greenInputStyle  = defaultInputStyle.Copy().Background(colorGreen).Foreground(colorWhite)
-	yellowInputStyle = defaultInputStyle.Copy().Background(colorYellow).Foreground(colorBlack)
-	greyInputStyle   = defaultInputStyle.Copy().Background(colorGrey).Foreground(colorWhite)
-	inputTextStyle   = lipgloss.NewStyle().Transform(strings.ToUpper)
-	helpTextStyle    = lipgloss.NewStyle().Foreground(colorLightGrey)
-	titleStyle       = lipgloss.NewStyle().PaddingBottom(1).Bold(true)
+	greenInputStyle   = defaultInputStyle.Copy().Background(colorGreen).Foreground(colorWhite)
+	yellowInputStyle  = defaultInputStyle.Copy().Background(colorYellow).Foreground(colorBlack)
+	greyInputStyle    = defaultInputStyle.Copy().Background(colorGrey).Foreground(colorWhite)
+	inputTextStyle    = lipgloss.NewStyle().Transform(strings.ToUpper)
+	selectedTextStyle = lipgloss.NewStyle().Underline(true)
+	helpTextStyle     = lipgloss.NewStyle().Foreground(colorLightGrey)
+	titleStyle        = lipgloss.NewStyle().PaddingBottom(1).Bold(true)
 )
 
 var inputStyle map[int]lipgloss.Style = map[int]lipgloss.Style{
@@ -85,68 +158,210 @@ var inputStyle map[int]lipgloss.Style = map[int]lipgloss.Style{
 	3: greenInputStyle,
 }
 
+// tileStyle returns the style of a letter tile. Compact tiles are used when
+// several boards have to fit on the screen.
+func tileStyle(feedback Feedback, compact bool) lipgloss.Style {
+	style := inputStyle[int(feedback)]
+	if compact {
+		return style.Copy().Padding(0, 1)
+	}
+	return style
+}
+
+// boardColumns returns how many boards are shown side by side.
+func boardColumns(boards int) int {
+	if boards >= 8 {
+		return 4
+	}
+	if boards >= 2 {
+		return 2
+	}
+	return 1
+}
+
 func (m model) BoardView() string {
 	title := "GUESSES"
-	if m.wordle.status == WIN {
+	if m.game.options.Mode == DAILY {
+		title = fmt.Sprintf("DAILY #%d", m.game.options.Puzzle)
+	} else if m.game.options.Mode != CLASSIC {
+		title = strings.ToUpper(m.game.options.Mode.String())
+	}
+	if m.game.status == WIN {
 		title = "YOU WIN"
-	} else if m.wordle.status == LOSE {
+	} else if m.timer.expired {
+		title = "TIME IS UP"
+	} else if m.game.status == LOSE {
 		title = "YOU LOSE"
+	} else if m.game.options.Hard {
+		title += " (HARD)"
+	}
+
+	boards := make([]string, len(m.game.boards))
+	for i, board := range m.game.boards {
+		boards[i] = m.boardView(board)
+	}
+	columns := boardColumns(len(boards))
+	grid := make([]string, 0, len(boards)/columns)
+	for i := 0; i < len(boards); i += columns {
+		grid = append(grid, lipgloss.JoinHorizontal(lipgloss.Top, boards[i:i+columns]...))
 	}
-	rows := make([]string, MAX_GUESSES+2) // +2 for the extra title row
-	rows = append(rows, titleStyle.Render(title))
+
+	return lipgloss.NewStyle().MarginRight(2).Render(lipgloss.JoinVertical(
+		lipgloss.Center,
+		titleStyle.Render(title),
+		lipgloss.JoinVertical(lipgloss.Left, grid...),
+	))
+}
+
+func (m model) boardView(board *Wordle) string {
+	compact := len(m.game.boards) > 2
+	rows := make([]string, 0, len(m.inputs))
 	for i := range m.inputs {
-		cols := make([]string, GUESS_LENGTH)
+		cols := make([]string, 0, board.length)
 		for j := range m.inputs[i] {
 			feedback := TBD
-			if m.wordle.board != nil && m.wordle.board[i] != nil {
-				feedback = m.wordle.board[i][j].feedback
+			text := m.inputs[i][j].View()
+			if board.board[i] != nil {
+				feedback = board.board[i][j].feedback
+			} else if m.colours != nil && i == board.attempt {
+				feedback = m.colours[j]
+				if j == m.tile {
+					text = selectedTextStyle.Render(text)
+				}
+			}
+			if board.status == WIN && i >= board.attempt {
+				// rows after the board was solved stay empty
+				text = strings.Repeat(" ", lipgloss.Width(text))
 			}
-			cols = append(cols, inputStyle[int(feedback)].Render(inputTextStyle.Render(m.inputs[i][j].View())))
+			cols = append(cols, tileStyle(feedback, compact).Render(inputTextStyle.Render(text)))
 		}
-		col := lipgloss.JoinHorizontal(lipgloss.Center, cols...)
-		rows = append(rows, col)
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Center, cols...))
 	}
 
-	return lipgloss.NewStyle().MarginRight(2).Render(lipgloss.JoinVertical(lipgloss.Center, rows...))
+	style := lipgloss.NewStyle()
+	if len(m.game.boards) > 1 {
+		style = style.MarginRight(2).MarginBottom(1)
+	}
+	return style.Render(lipgloss.JoinVertical(lipgloss.Center, rows...))
 }
 
 func (m model) AsideView() string {
+	top := m.AlphabetView()
+	if m.stats {
+		top = m.StatisticsView()
+	}
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
-		m.AlphabetView(),
+		top,
+		m.ColoursView(),
+		m.remaining.View(),
 		m.SuggestionView(),
 		m.HintView(),
+		m.ChallengeView(),
 		m.HelpView(),
 	)
 }
 
 func (m model) SuggestionView() string {
 	var s strings.Builder
-	if m.suggestions {
-		s.WriteString(fmt.Sprintf("Try: '%s'\n", m.wordle.suggestNextGuess()))
+	if m.suggestions && m.game.status == ONGOING {
+		if !m.suggested.current(m) {
+			s.WriteString(fmt.Sprintf("Try (%s): ...\n", m.solver.name()))
+		} else {
+			s.WriteString(fmt.Sprintf("Try (%s):\n", m.solver.name()))
+			for _, guess := range m.suggested.ranked {
+				if m.solver.unit() == "" {
+					s.WriteString(fmt.Sprintf("  '%s'\n", guess.word))
+				} else {
+					s.WriteString(fmt.Sprintf("  '%s' %5.2f %s\n", guess.word, guess.score, m.solver.unit()))
+				}
+			}
+		}
 	}
 	return helpTextStyle.Render(s.String())
 }
 
+// suggestionsMsg delivers the suggestions ranked in the background for an
+// attempt of a game.
+type suggestionsMsg struct {
+	game    *Game
+	attempt int
+	solver  Solver
+	ranked  []RankedGuess
+}
+
+// current reports whether the suggestions were ranked for the current
+// attempt and solver of m.
+func (msg suggestionsMsg) current(m model) bool {
+	return msg.game == m.game && msg.attempt == m.game.attempt && msg.solver == m.solver
+}
+
+// suggest ranks the guesses for the current state of the game in the
+// background if the suggestions are shown and not already being ranked.
+func (m *model) suggest() tea.Cmd {
+	if !m.suggestions || m.game.status != ONGOING {
+		return nil
+	}
+	if m.pending.current(*m) {
+		return nil
+	}
+	game, attempt, solver := m.game, m.game.attempt, m.solver
+	m.pending = suggestionsMsg{game: game, attempt: attempt, solver: solver}
+	task := game.rankTask(solver, SUGGESTIONS)
+	return func() tea.Msg {
+		return suggestionsMsg{game: game, attempt: attempt, solver: solver, ranked: task()}
+	}
+}
+
 func (m model) HintView() string {
 	var s strings.Builder
+	if m.warning != "" {
+		s.WriteString(fmt.Sprintf("%s\n", m.warning))
+	}
 	if m.hints {
 		s.WriteString(fmt.Sprintf("Hint: %s\n", m.hint))
 	}
 	return helpTextStyle.Render(s.String())
 }
 
+func (m model) StatisticsView() string {
+	highlight := 0
+	if m.game.status == WIN {
+		highlight = m.game.attempt
+	}
+	key := statsKey(m.game.options)
+	if m.game.options.Mode == SPEEDRUN {
+		record, ok := m.statistics.Speedruns[key]
+		if !ok {
+			record = &SpeedrunRecord{}
+		}
+		return SpeedrunView("Statistics: "+key, record)
+	}
+	record, ok := m.statistics.Modes[key]
+	if !ok {
+		record = NewRecord()
+	}
+	return StatisticsView("Statistics: "+key, record, m.game.options.Guesses, highlight)
+}
+
+func (m model) ChallengeView() string {
+	if !m.prompting {
+		return ""
+	}
+	return lipgloss.NewStyle().MarginBottom(1).Render(m.prompt.View())
+}
+
 func (m model) HelpView() string {
 	if m.help {
 		return helpTextStyle.Render(lipgloss.JoinHorizontal(
 			lipgloss.Center,
 			lipgloss.NewStyle().MarginRight(2).Render(lipgloss.JoinVertical(
 				lipgloss.Left,
-				"?", "C-c", "C-r", "Return", "C-h", "C-s",
+				"?", "C-c", "C-r", "Return", "C-h", "C-s", "C-n", "C-d", "C-o", "C-t", "C-l", "C-y",
 			)),
 			lipgloss.JoinVertical(
 				lipgloss.Right,
-				"Help", "Quit", "New Game", "Submit Guess", "Show Hints", "Show Suggestions",
+				"Help", "Quit", "New Game", "Submit Guess", "Show Hints", "Show Suggestions", "Next Solver", "Hard Mode", "Play Challenge", "Statistics", "Remaining Words", "Copy Result",
 			),
 		))
 	}
@@ -159,36 +374,14 @@ func (m model) HelpView() string {
 }
 
 func (m model) AlphabetView() string {
-	alphabet := [][]rune{
-		{'q', 'w', 'e', 'r', 't', 'y', 'u', 'i', 'o', 'p'},
-		{'a', 's', 'd', 'f', 'h', 'j', 'k', 'l'},
-		{'z', 'x', 'c', 'v', 'b', 'n', 'm'},
-	}
+	alphabet := m.game.language().keyboard
 	view := make([][]string, len(alphabet))
 	for i := range view {
-		view[i] = make([]string, len(alphabet[0]))
+		view[i] = make([]string, len(alphabet[i]))
 	}
 	for row := range alphabet {
-	outer:
 		for col := range alphabet[row] {
-			char := alphabet[row][col]
-			for _, char_idx := range m.wordle.assign {
-				if char_idx == alphabetIdx(byte(char)) {
-					view[row][col] = greenInputStyle.Padding(1, 1).Render(strings.ToUpper(string(char)))
-					continue outer
-				}
-			}
-			for char_idx, include := range m.wordle.include {
-				if char_idx == alphabetIdx(byte(char)) && include {
-					view[row][col] = yellowInputStyle.Padding(1, 1).Render(strings.ToUpper(string(char)))
-					continue outer
-				}
-				if char_idx == alphabetIdx(byte(char)) && !include {
-					view[row][col] = greyInputStyle.Padding(1, 1).Render(strings.ToUpper(string(char)))
-					continue outer
-				}
-			}
-			view[row][col] = defaultInputStyle.Padding(1, 1).Render(strings.ToUpper(string(char)))
+			view[row][col] = m.keyView(alphabet[row][col])
 		}
 	}
 
@@ -204,13 +397,119 @@ func (m model) AlphabetView() string {
 	))
 }
 
+// keyView renders a single key of the keyboard. With several boards the key
+// is split into one segment per board, laid out like the boards themselves.
+func (m model) keyView(char rune) string {
+	char_idx := m.game.language().idx(char)
+	letter := strings.ToUpper(string(char))
+	boards := m.game.boards
+	if len(boards) == 1 {
+		return tileStyle(boards[0].letterFeedback(char_idx), false).Render(letter)
+	}
+
+	padding := 1
+	if len(boards) > 4 {
+		padding = 0
+	}
+	columns := boardColumns(len(boards))
+	rows := make([]string, 0, len(boards)/columns)
+	for i := 0; i < len(boards); i += columns {
+		segments := make([]string, 0, columns)
+		for j := i; j < i+columns; j++ {
+			text := " "
+			if j == 0 {
+				text = letter
+			}
+			style := tileStyle(boards[j].letterFeedback(char_idx), true).Copy().Padding(0, padding)
+			segments = append(segments, style.Render(text))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, segments...))
+	}
+	return lipgloss.NewStyle().MarginRight(1).MarginBottom(1).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
 func (m *model) newGame() {
-	m.wordle = NewWordle()
-	inputs := make([]WordInput, MAX_GUESSES+1)
-	for i := range inputs {
-		inputs[i] = NewWordInput()
+	game, err := startGame(m.options)
+	if err != nil {
+		m.warning = err.Error()
+		return
+	}
+	m.game = game
+	m.warning = ""
+	m.inputs = newInputs(game)
+	m.colours = nil
+	m.cursor = 0
+	m.stats = false
+	m.timer.reset(m.options)
+}
+
+// playChallenge starts a one-off game from a challenge code. The next new
+// game is played with the regular options again.
+func (m *model) playChallenge(code string) error {
+	options, err := decodeChallenge(code)
+	if err != nil {
+		return err
 	}
-	m.inputs = inputs
+	game, err := startGame(options)
+	if err != nil {
+		return err
+	}
+	m.game = game
+	m.warning = ""
+	m.inputs = newInputs(game)
+	m.colours = nil
+	m.cursor = 0
+	m.stats = false
+	m.timer.reset(options)
+	return nil
+}
+
+// resume continues a saved game, including the state of the panels.
+func (m *model) resume(saved savedGame) error {
+	game, err := saved.restore()
+	if err != nil {
+		return err
+	}
+	m.game = game
+	m.options = saved.Options
+	m.inputs = newInputs(game)
+	m.colours = nil
+	m.cursor = 0
+	m.timer.reset(game.options)
+	m.help = saved.Help
+	m.hints = saved.Hints
+	m.suggestions = saved.Suggestions
+	m.stats = saved.Stats
+	return nil
+}
+
+// quit saves the current game so it can be resumed on the next launch.
+func (m *model) quit() tea.Cmd {
+	if err := saveGame(*m); err != nil {
+		m.warning = err.Error()
+	}
+	return tea.Quit
+}
+
+func (m *model) handlePrompt(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "ctrl+c":
+		return m.quit()
+	case tea.KeyEsc.String():
+		m.prompting = false
+		m.prompt.Blur()
+	case tea.KeyEnter.String():
+		if err := m.playChallenge(m.prompt.Value()); err != nil {
+			m.warning = err.Error()
+			return cmd
+		}
+		m.prompting = false
+		m.prompt.Blur()
+	default:
+		m.prompt, cmd = m.prompt.Update(msg)
+	}
+	return cmd
 }
 
 func (m model) Init() tea.Cmd {
@@ -227,22 +526,49 @@ func (m model) View() string {
 		lipgloss.Center,
 		lipgloss.JoinHorizontal(
 			lipgloss.Bottom,
-			m.BoardView(),
+			lipgloss.JoinVertical(lipgloss.Center, m.TimerView(), m.BoardView()),
 			m.AsideView(),
 		),
 	)
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	m.remaining.refresh(m.game)
+	return m, tea.Batch(cmd, m.suggest())
+}
+
+func (m model) update(msg tea.Msg) (model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+	case suggestionsMsg:
+		m.suggested = msg
+	case tickMsg:
+		cmd = m.handleTick(msg)
 	case tea.KeyMsg:
+		m.warning = ""
+		if now := time.Now(); m.timer.due(now) {
+			// the key was pressed before the last tick arrived
+			m.timeout(now)
+		}
+		if m.prompting {
+			return m, m.handlePrompt(msg)
+		}
+		if m.colours != nil && msg.String() != "ctrl+c" {
+			return m, m.handleColours(msg)
+		}
+		if m.remaining.shown {
+			if handled, cmd := m.remaining.handleKey(msg); handled {
+				return m, cmd
+			}
+		}
 		switch msg.String() {
 		case "ctrl+c":
-			return m, tea.Quit
+			cmd = m.quit()
+			return m, cmd
 		case "ctrl+r":
 			m.newGame()
 			return m, cmd
@@ -256,27 +582,66 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.hints = !m.hints
 		case "ctrl+s":
 			m.suggestions = !m.suggestions
+		case "ctrl+n":
+			m.solver = nextSolver(m.solver)
+			m.suggestions = true
+		case "ctrl+d":
+			if err := m.game.setHardMode(!m.game.options.Hard); err != nil {
+				m.warning = m.game.message
+				return m, cmd
+			}
+			m.options.Hard = m.game.options.Hard
+		case "ctrl+t":
+			m.stats = !m.stats
+		case "ctrl+l":
+			m.remaining.shown = !m.remaining.shown
+		case "ctrl+y":
+			if m.game.status == ONGOING {
+				m.warning = "the result can be copied once the game is over"
+				return m, cmd
+			}
+			m.warning = "copied result to clipboard"
+			return m, copyToClipboard(shareText(m.game, m.shareStyle))
+		case "ctrl+o":
+			m.prompting = true
+			m.prompt.Reset()
+			return m, m.prompt.Focus()
 		default:
-			if m.wordle.status != ONGOING {
+			if m.game.status != ONGOING {
+				if m.timer.grace(time.Now()) {
+					// keep the result of a timeout from being typed away
+					return m, cmd
+				}
 				m.newGame()
 				return m, cmd
 			}
-			if !(msg.String() >= "a" && msg.String() <= "z") {
+			if !m.isLetter(msg) {
 				return m, cmd
 			}
+			if m.timer.enabled() && !m.timer.running {
+				cmd = m.timer.begin(time.Now())
+			}
 			m.handleKeyAlphabet(msg)
 		}
 	}
 	return m, cmd
 }
 
+// isLetter reports whether msg is a single letter of the game's alphabet.
+func (m model) isLetter(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && m.game.language().contains(msg.Runes[0])
+}
+
 func (m *model) handleKeyBackspace(msg tea.KeyMsg) tea.Cmd {
-	current_input := &m.inputs[m.wordle.attempt][m.cursor]
+	if m.game.status != ONGOING {
+		return nil
+	}
+	current_input := &m.inputs[m.game.attempt][m.cursor]
 	current_input.Focus()
 	var cmd tea.Cmd
 	if current_input.Value() == "" && m.cursor > 0 {
 		m.cursor--
-		current_input = &m.inputs[m.wordle.attempt][m.cursor]
+		current_input = &m.inputs[m.game.attempt][m.cursor]
 	}
 	*current_input, cmd = current_input.Update(msg)
 	if m.cursor > 0 {
@@ -287,46 +652,224 @@ func (m *model) handleKeyBackspace(msg tea.KeyMsg) tea.Cmd {
 
 func (m *model) handleKeyEnter() tea.Cmd {
 	var cmd tea.Cmd
-	if m.cursor != GUESS_LENGTH-1 {
+	if m.game.status != ONGOING {
+		return cmd
+	}
+	if m.cursor != m.game.length()-1 {
 		return cmd
 	}
 	word := ""
-	for i := range m.inputs[m.wordle.attempt] {
-		word += m.inputs[m.wordle.attempt][i].Value()
+	for i := range m.inputs[m.game.attempt] {
+		word += m.inputs[m.game.attempt][i].Value()
 	}
 
-	guess, err := NewGuess(word)
+	guess, err := NewGuess(word, m.game.language(), m.game.length())
 	if err == nil {
-		m.wordle.validateFull(guess)
-		m.hint = m.wordle.message
+		m.hint = m.game.hint(guess)
 	}
 
-	if err := m.wordle.guess(strings.ToLower(word)); err != nil {
-		m.hint = m.wordle.message
+	if m.game.options.Mode == ASSIST {
+		err = m.enterFeedback(strings.ToLower(word))
+	} else {
+		err = m.game.guess(strings.ToLower(word))
+	}
+	if err != nil {
+		m.hint = m.game.message
+		var hardModeErr *HardModeError
+		if errors.As(err, &hardModeErr) {
+			m.warning = hardModeErr.message
+		}
+		return cmd
+	}
+	if m.colours != nil {
 		return cmd
 	}
 	m.cursor = 0
+	if m.game.options.Mode == SPEEDRUN && m.game.status != ONGOING {
+		m.nextPuzzle()
+		return cmd
+	}
+	if m.game.options.Mode == DAILY {
+		if err := saveDaily(m.game); err != nil {
+			m.warning = err.Error()
+		}
+	}
+	if m.game.status != ONGOING {
+		m.timer.stop(time.Now())
+		statistics, err := recordGame(m.game)
+		if err != nil {
+			m.warning = err.Error()
+			return cmd
+		}
+		m.statistics = statistics
+		m.stats = m.game.options.Mode != ASSIST
+		m.share = shareText(m.game, m.shareStyle)
+	}
 	return cmd
 }
 
 func (m *model) handleKeyAlphabet(msg tea.KeyMsg) tea.Cmd {
-	current_input := &m.inputs[m.wordle.attempt][m.cursor]
+	current_input := &m.inputs[m.game.attempt][m.cursor]
 	current_input.Focus()
 	var cmd tea.Cmd
-	if current_input.Value() != "" && m.cursor < GUESS_LENGTH-1 {
+	if current_input.Value() != "" && m.cursor < m.game.length()-1 {
 		m.cursor++
-		current_input = &m.inputs[m.wordle.attempt][m.cursor]
+		current_input = &m.inputs[m.game.attempt][m.cursor]
 	}
 	*current_input, cmd = current_input.Update(msg)
-	if m.cursor < GUESS_LENGTH-1 {
+	if m.cursor < m.game.length()-1 {
 		m.cursor++
 	}
 	return cmd
 }
 
+// commands are the subcommands of the binary, without one the game is
+// started.
+var commands = map[string]func(args []string) error{
+	"challenge": runChallenge,
+	"bench":     runBench,
+	"solve":     runSolve,
+	"referee":   runReferee,
+	"serve":     runServe,
+	"host":      runHost,
+	"join":      runJoin,
+}
+
 func main() {
-	p := tea.NewProgram(NewModel())
-	if _, err := p.Run(); err != nil {
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(os.Args[2:]); err != nil {
+				if err != flag.ErrHelp {
+					fmt.Fprintln(os.Stderr, err)
+				}
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	config, err := loadConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	options := DefaultOptions()
+	flag.BoolVar(&options.Hard, "hard", false, "start in hard mode, revealed hints must be used in subsequent guesses")
+	flag.IntVar(&options.Guesses, "guesses", DEFAULT_GUESSES, fmt.Sprintf("number of allowed guesses (%d-%d)", MIN_GUESSES, MAX_GUESSES))
+	flag.IntVar(&options.Length, "length", DEFAULT_WORD_LENGTH, fmt.Sprintf("number of letters per word (%d-%d)", MIN_WORD_LENGTH, MAX_WORD_LENGTH))
+	flag.IntVar(&options.Boards, "boards", 1, fmt.Sprintf("number of boards played at once, one of %v", BOARD_COUNTS))
+	flag.Func("mode", "game mode, 'classic', 'daily', 'absurdle' where the solution dodges your guesses, 'assist' to enter the feedback of a puzzle played elsewhere, 'countdown' to solve it before the time runs out or 'speedrun' to solve as many as possible in time", func(name string) error {
+		mode, err := parseMode(name)
+		options.Mode = mode
+		return err
+	})
+	flag.Func("date", "play the daily puzzle of a past date, in the format YYYY-MM-DD", func(value string) error {
+		puzzle, err := parseDailyDate(value, time.Now())
+		options.Mode = DAILY
+		options.Puzzle = puzzle
+		return err
+	})
+	flag.Func("lang", fmt.Sprintf("language of the words, one of %s (default %s)", strings.Join(languageCodes(), ", "), DEFAULT_LANGUAGE), func(code string) error {
+		_, err := language(code)
+		options.Lang = code
+		return err
+	})
+	limit := flag.Duration("time", 0, fmt.Sprintf("time limit of the countdown and speedrun modes (default %s and %s)", COUNTDOWN_LIMIT, SPEEDRUN_LIMIT))
+	solutionsFile := flag.String("solutions", config.Solutions, "file with the solutions to play instead of the built-in ones, as CSV or a word per line")
+	guessesFile := flag.String("guess-list", config.GuessList, "file with the allowed guesses besides the solutions, as CSV or a word per line (named --guess-list as --guesses sets the number of guesses)")
+	challenge := flag.String("challenge", "", "play the challenge with the given code")
+	discard := flag.Bool("new", false, "discard the saved game and start a new one")
+	plain := flag.Bool("plain", false, "play line by line over stdin and stdout without the TUI, exits with 0 for a win and 1 for a loss")
+	printShare := flag.Bool("print-share", false, "print the result grid of the last finished game on exit")
+	shareStyle := EMOJI
+	flag.Func("share-style", "symbols of the result grid, 'emoji', 'contrast' or 'ascii'", func(name string) error {
+		style, err := parseShareStyle(name)
+		shareStyle = style
+		return err
+	})
+	flag.BoolVar(&cachePatterns, "cache-patterns", false, "keep the feedback patterns of the solvers in the data directory")
+	solverName := flag.String("solver", SOLVERS[0], fmt.Sprintf("strategy of the suggestions, one of %s", strings.Join(SOLVERS, ", ")))
+	flag.Parse()
+
+	solver, err := NewSolver(*solverName, time.Now().UnixNano())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+
+	if options.Mode == DAILY && options.Puzzle == 0 {
+		puzzle, err := dailyNumber(time.Now())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		options.Puzzle = puzzle
+	}
+
+	if options.timed() {
+		options.Limit = *limit
+		if options.Limit == 0 {
+			options.Limit = defaultLimit(options.Mode)
+		}
+	}
+
+	guessesSet := false
+	flag.Visit(func(f *flag.Flag) {
+		guessesSet = guessesSet || f.Name == "guesses"
+	})
+	if !guessesSet {
+		options.Guesses = defaultGuesses(options.Boards)
+	}
+
+	lang, _ := language(options.Lang)
+	if err := loadWordLists(lang, options.Length, *solutionsFile, *guessesFile); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *plain {
+		os.Exit(runPlain(options, *challenge, *printShare, shareStyle))
+	}
+
+	m, err := NewModel(options)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	m.shareStyle = shareStyle
+	m.solver = solver
+	if *discard {
+		if err := removeData(SAVE_FILE); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	} else if saved, err := loadGame(); err != nil {
+		m.warning = fmt.Sprintf("could not load saved game: %s", err)
+	} else if saved != nil {
+		if err := m.resume(*saved); err != nil {
+			m.warning = fmt.Sprintf("could not resume saved game: %s", err)
+		} else if ignored := gameFlags(flag.CommandLine); len(ignored) > 0 {
+			m.warning = fmt.Sprintf("resumed the saved game without %s, start with --new to discard it", strings.Join(ignored, " "))
+		}
+	}
+	if *challenge != "" {
+		if err := m.playChallenge(*challenge); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	p := tea.NewProgram(m)
+	final, err := p.Run()
+	if err != nil {
+		os.Exit(1)
+	}
+	if m, ok := final.(model); ok {
+		if m.warning != "" {
+			fmt.Fprintln(os.Stderr, m.warning)
+		}
+		if *printShare && m.share != "" {
+			fmt.Println(m.share)
+		}
+	}
 }
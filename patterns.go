@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	PATTERNS_MAGIC   = "WTPT"
+	PATTERNS_VERSION = 1
+)
+
+// cachePatterns keeps the pattern tables in the data directory, so they are
+// only computed once per word list.
+var cachePatterns = false
+
+// PatternTable holds the feedback pattern of every guess against every
+// solution of a word length. Patterns below 256 are stored in a single byte,
+// longer words need two.
+type PatternTable struct {
+	length    int
+	guesses   map[string]int
+	solutions map[string]int
+	width     int // bytes per pattern
+	data      []byte
+}
+
+var (
+	patternsMu    sync.Mutex
+	patternsCache = make(map[dictionaryKey]*PatternTable)
+)
+
+// patternTable returns the pattern table of the given language and word
+// length. It is computed on first use, or read from the data directory if
+// cachePatterns is set and the word lists did not change.
+func patternTable(lang *Language, length int) (*PatternTable, error) {
+	patternsMu.Lock()
+	defer patternsMu.Unlock()
+	key := dictionaryKey{lang: lang.code, length: length}
+	if table, ok := patternsCache[key]; ok {
+		return table, nil
+	}
+
+	solutionTrie, guessTrie, err := loadTries(lang, length)
+	if err != nil {
+		return nil, err
+	}
+	guesses, solutions := guessTrie.words(), solutionTrie.words()
+	checksum := patternChecksum(guesses, solutions)
+	name := fmt.Sprintf("patterns_%d.bin", length)
+	if lang.code != DEFAULT_LANGUAGE {
+		name = fmt.Sprintf("patterns_%s_%d.bin", lang.code, length)
+	}
+
+	var table *PatternTable
+	if cachePatterns {
+		if data, err := readFile(name); err == nil {
+			table, _ = decodePatternTable(data, checksum, guesses, solutions)
+		}
+	}
+	if table == nil {
+		table = NewPatternTable(guesses, solutions)
+		if cachePatterns {
+			// the table can always be computed again
+			writeFile(name, table.encode(checksum))
+		}
+	}
+	patternsCache[key] = table
+	return table, nil
+}
+
+func newPatternIndex(words []string) map[string]int {
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		index[word] = i
+	}
+	return index
+}
+
+func patternWidth(length int) int {
+	if patternCount(length) <= 256 {
+		return 1
+	}
+	return 2
+}
+
+// NewPatternTable computes the patterns of guesses against solutions, one
+// row per guess, spreading the rows over all CPU cores.
+func NewPatternTable(guesses []string, solutions []string) *PatternTable {
+	length := 0
+	if len(guesses) > 0 {
+		length = utf8.RuneCountInString(guesses[0])
+	}
+	table := &PatternTable{
+		length:    length,
+		guesses:   newPatternIndex(guesses),
+		solutions: newPatternIndex(solutions),
+		width:     patternWidth(length),
+	}
+	table.data = make([]byte, len(guesses)*len(solutions)*table.width)
+
+	workers := runtime.NumCPU()
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := worker; i < len(guesses); i += workers {
+				for j, solution := range solutions {
+					table.set(i, j, feedbackPattern(guesses[i], solution))
+				}
+			}
+		}(worker)
+	}
+	wg.Wait()
+	return table
+}
+
+func (t *PatternTable) offset(guess int, solution int) int {
+	return (guess*len(t.solutions) + solution) * t.width
+}
+
+func (t *PatternTable) set(guess int, solution int, pattern int) {
+	offset := t.offset(guess, solution)
+	if t.width == 1 {
+		t.data[offset] = byte(pattern)
+		return
+	}
+	binary.LittleEndian.PutUint16(t.data[offset:], uint16(pattern))
+}
+
+func (t *PatternTable) get(guess int, solution int) int {
+	offset := t.offset(guess, solution)
+	if t.width == 1 {
+		return int(t.data[offset])
+	}
+	return int(binary.LittleEndian.Uint16(t.data[offset:]))
+}
+
+// patterns writes the pattern of guess against each candidate into buf.
+// Words missing from the table are scored directly.
+func (t *PatternTable) patterns(guess string, candidates []string, buf []int) []int {
+	buf = buf[:0]
+	i, ok := -1, false
+	if t != nil {
+		i, ok = t.guesses[guess]
+	}
+	for _, candidate := range candidates {
+		if ok {
+			if j, found := t.solutions[candidate]; found {
+				buf = append(buf, t.get(i, j))
+				continue
+			}
+		}
+		buf = append(buf, feedbackPattern(guess, candidate))
+	}
+	return buf
+}
+
+// patternChecksum identifies the word lists a table was computed for.
+func patternChecksum(guesses []string, solutions []string) [sha256.Size]byte {
+	hash := sha256.New()
+	for _, word := range guesses {
+		hash.Write([]byte(word))
+		hash.Write([]byte{'\n'})
+	}
+	hash.Write([]byte{0})
+	for _, word := range solutions {
+		hash.Write([]byte(word))
+		hash.Write([]byte{'\n'})
+	}
+	var checksum [sha256.Size]byte
+	copy(checksum[:], hash.Sum(nil))
+	return checksum
+}
+
+// encode serialises the table behind a header of the magic, the version and
+// the checksum of the word lists.
+func (t *PatternTable) encode(checksum [sha256.Size]byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(PATTERNS_MAGIC)
+	b.WriteByte(PATTERNS_VERSION)
+	b.Write(checksum[:])
+	b.Write(t.data)
+	return b.Bytes()
+}
+
+func decodePatternTable(data []byte, checksum [sha256.Size]byte, guesses []string, solutions []string) (*PatternTable, error) {
+	header := len(PATTERNS_MAGIC) + 1 + sha256.Size
+	if len(data) < header || string(data[:len(PATTERNS_MAGIC)]) != PATTERNS_MAGIC {
+		return nil, fmt.Errorf("Error: Not a pattern table")
+	}
+	if data[len(PATTERNS_MAGIC)] != PATTERNS_VERSION {
+		return nil, fmt.Errorf("Error: Unsupported pattern table version %d", data[len(PATTERNS_MAGIC)])
+	}
+	if !bytes.Equal(data[len(PATTERNS_MAGIC)+1:header], checksum[:]) {
+		return nil, fmt.Errorf("Error: Pattern table was computed for other word lists")
+	}
+
+	length := 0
+	if len(guesses) > 0 {
+		length = utf8.RuneCountInString(guesses[0])
+	}
+	table := &PatternTable{
+		length:    length,
+		guesses:   newPatternIndex(guesses),
+		solutions: newPatternIndex(solutions),
+		width:     patternWidth(length),
+		data:      data[header:],
+	}
+	if len(table.data) != len(guesses)*len(solutions)*table.width {
+		return nil, fmt.Errorf("Error: Pattern table is truncated")
+	}
+	return table, nil
+}
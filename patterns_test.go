@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPatternTable(t *testing.T) {
+	guesses := []string{"earth", "geese", "eerie", "error"}
+	solutions := []string{"speed", "abide", "rarer"}
+	table := NewPatternTable(guesses, solutions)
+	for i, guess := range guesses {
+		for j, solution := range solutions {
+			if pattern := table.get(i, j); pattern != feedbackPattern(guess, solution) {
+				t.Errorf("Expected pattern %d for '%s' against '%s' but got %d", feedbackPattern(guess, solution), guess, solution, pattern)
+			}
+		}
+	}
+
+	// unknown words are scored directly
+	patterns := table.patterns("quick", []string{"speed", "llama"}, nil)
+	if patterns[0] != feedbackPattern("quick", "speed") || patterns[1] != feedbackPattern("quick", "llama") {
+		t.Errorf("Expected patterns of words missing from the table but got %v", patterns)
+	}
+}
+
+func TestPatternTableWide(t *testing.T) {
+	guesses := []string{"abroad", "crafty"}
+	solutions := []string{"crafty", "bronze"}
+	table := NewPatternTable(guesses, solutions)
+	if table.width != 2 {
+		t.Fatalf("Expected 2 bytes per pattern for 6 letters but got %d", table.width)
+	}
+	if pattern := table.get(1, 0); pattern != patternCount(6)-1 {
+		t.Errorf("Expected the solved pattern %d but got %d", patternCount(6)-1, pattern)
+	}
+}
+
+func TestPatternTableEncoding(t *testing.T) {
+	guesses := []string{"earth", "geese"}
+	solutions := []string{"speed", "abide"}
+	table := NewPatternTable(guesses, solutions)
+	checksum := patternChecksum(guesses, solutions)
+
+	decoded, err := decodePatternTable(table.encode(checksum), checksum, guesses, solutions)
+	if err != nil {
+		t.Fatalf("Expected the table to be decoded but got %s", err)
+	}
+	if string(decoded.data) != string(table.data) {
+		t.Errorf("Expected the decoded patterns to match")
+	}
+
+	other := patternChecksum(guesses, []string{"speed"})
+	if _, err := decodePatternTable(table.encode(other), checksum, guesses, solutions); err == nil {
+		t.Errorf("Expected a table of other word lists to be rejected")
+	}
+	if _, err := decodePatternTable(table.encode(checksum)[:20], checksum, guesses, solutions); err == nil {
+		t.Errorf("Expected a truncated table to be rejected")
+	}
+}
+
+func TestPatternTableCache(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	cachePatterns = true
+	defer func() { cachePatterns = false }()
+	forget := func() {
+		patternsMu.Lock()
+		delete(patternsCache, dictionaryKey{lang: DEFAULT_LANGUAGE, length: 7})
+		patternsMu.Unlock()
+	}
+	forget()
+	defer forget()
+
+	table, err := patternTable(LANGUAGES[DEFAULT_LANGUAGE], 7)
+	if err != nil {
+		t.Fatalf("Expected a pattern table but got %s", err)
+	}
+	dir, _ := dataDir()
+	if _, err := os.Stat(filepath.Join(dir, "patterns_7.bin")); err != nil {
+		t.Fatalf("Expected the table to be cached but got %s", err)
+	}
+
+	forget()
+	cached, err := patternTable(LANGUAGES[DEFAULT_LANGUAGE], 7)
+	if err != nil {
+		t.Fatalf("Expected the cached pattern table but got %s", err)
+	}
+	if string(cached.data) != string(table.data) {
+		t.Errorf("Expected the cached patterns to match")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Exit codes of the plain mode.
+const (
+	EXIT_WIN   = 0
+	EXIT_LOSE  = 1
+	EXIT_ERROR = 2 // also when the input ends before the game does
+)
+
+// playPlain plays game line by line over r and w without the TUI, for pipes
+// and terminals that can't display it. Every line read is a guess, in assist
+// mode followed by its feedback like "crane gybgg". Progress on the daily
+// puzzle is saved after every guess. It returns the exit code for the outcome
+// of the game.
+func playPlain(game *Game, r io.Reader, w io.Writer) int {
+	fmt.Fprintf(w, "%s: %d letters, %d guesses", strings.ToUpper(game.options.Mode.String()), game.length(), game.options.Guesses)
+	if len(game.boards) > 1 {
+		fmt.Fprintf(w, ", %d boards", len(game.boards))
+	}
+	if game.options.Hard {
+		fmt.Fprint(w, ", hard mode")
+	}
+	fmt.Fprintln(w)
+	if game.options.Mode == ASSIST {
+		fmt.Fprintln(w, "Enter every guess with its feedback, g for green, y for yellow and b for grey, e.g. crane gybgg")
+	}
+	// a restored daily puzzle continues where it was left
+	for attempt := 0; attempt < game.attempt; attempt++ {
+		fmt.Fprintln(w, plainRow(game, attempt))
+	}
+
+	scanner := bufio.NewScanner(r)
+	for game.status == ONGOING {
+		fmt.Fprintf(w, "Guess %d/%d: ", game.attempt+1, game.options.Guesses)
+		if !scanner.Scan() {
+			fmt.Fprintln(w)
+			return EXIT_ERROR
+		}
+		fields := strings.Fields(strings.ToLower(scanner.Text()))
+		if len(fields) == 0 {
+			continue
+		}
+
+		word := fields[0]
+		hint := ""
+		if guess, err := NewGuess(word, game.language(), game.length()); err == nil {
+			hint = game.hint(guess)
+		}
+		var err error
+		if game.options.Mode == ASSIST {
+			var feedback []Feedback
+			if len(fields) != 2 {
+				err = fmt.Errorf("Error: Expected the guess followed by its feedback")
+			} else if feedback, err = parsePattern(fields[1], game.length()); err == nil {
+				err = game.enter(word, feedback)
+			}
+		} else {
+			err = game.guess(word)
+		}
+		if err != nil {
+			fmt.Fprintln(w, err)
+			if game.message != "" {
+				fmt.Fprintf(w, "  %s\n", game.message)
+			}
+			continue
+		}
+
+		fmt.Fprintln(w, plainRow(game, game.attempt-1))
+		if hint != "" {
+			fmt.Fprintf(w, "  Hint: %s\n", hint)
+		}
+		if game.options.Mode == DAILY {
+			if err := saveDaily(game); err != nil {
+				fmt.Fprintln(w, err)
+			}
+		}
+	}
+
+	if game.status == WIN {
+		fmt.Fprintf(w, "You win! %d/%d\n", game.attempt, game.options.Guesses)
+		return EXIT_WIN
+	}
+	solutions := make([]string, 0, len(game.boards))
+	for _, board := range game.boards {
+		if board.status == LOSE {
+			solutions = append(solutions, strings.ToUpper(board.solution))
+		}
+	}
+	if game.options.Mode == ASSIST {
+		fmt.Fprintln(w, "You lose!")
+	} else {
+		fmt.Fprintf(w, "You lose! %s\n", strings.Join(solutions, " "))
+	}
+	return EXIT_LOSE
+}
+
+// plainRow spells out the feedback of every board for a guess, e.g.
+// "ADEPT ybyby bbgbb". Boards solved earlier are left blank.
+func plainRow(game *Game, attempt int) string {
+	patterns := make([]string, len(game.boards))
+	for i, board := range game.boards {
+		if attempt < board.attempt && board.board[attempt] != nil {
+			patterns[i] = board.board[attempt].pattern()
+		} else {
+			patterns[i] = strings.Repeat(" ", game.length())
+		}
+	}
+	return strings.TrimRight(fmt.Sprintf("%s %s", strings.ToUpper(game.history[attempt]), strings.Join(patterns, " ")), " ")
+}
+
+// runPlain plays a game of options, or of the challenge code if given, in
+// plain mode and returns the exit code.
+func runPlain(options Options, challenge string, printShare bool, style ShareStyle) int {
+	if challenge != "" {
+		decoded, err := decodeChallenge(challenge)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return EXIT_ERROR
+		}
+		options = decoded
+	}
+	if options.timed() {
+		fmt.Fprintln(os.Stderr, "Error: Timed modes can't be played in plain mode")
+		return EXIT_ERROR
+	}
+	game, err := startGame(options)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return EXIT_ERROR
+	}
+	code := playPlain(game, os.Stdin, os.Stdout)
+	if printShare && game.status != ONGOING {
+		fmt.Println()
+		fmt.Println(shareText(game, style))
+	}
+	return code
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlainWin(t *testing.T) {
+	game := NewTestGame("earth")
+	var out strings.Builder
+	code := playPlain(game, strings.NewReader("adept\n\nxxxxx\nEARTH\n"), &out)
+	if code != EXIT_WIN {
+		t.Errorf("Expected exit code %d but got %d", EXIT_WIN, code)
+	}
+	for _, expected := range []string{"ADEPT ybyby", "'xxxxx' is not a valid word", "EARTH ggggg", "You win! 2/6"} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("Expected '%s' in the output but got\n%s", expected, out.String())
+		}
+	}
+}
+
+func TestPlainLose(t *testing.T) {
+	game := NewTestGame("earth")
+	input := strings.Repeat("adept\n", game.options.Guesses)
+	var out strings.Builder
+	if code := playPlain(game, strings.NewReader(input), &out); code != EXIT_LOSE {
+		t.Errorf("Expected exit code %d but got %d", EXIT_LOSE, code)
+	}
+	if !strings.Contains(out.String(), "You lose! EARTH") {
+		t.Errorf("Expected the solution to be revealed but got\n%s", out.String())
+	}
+	// the hint explains why the repeated guess can't be the solution
+	if !strings.Contains(out.String(), "Hint: ") {
+		t.Errorf("Expected hints in the output but got\n%s", out.String())
+	}
+}
+
+func TestPlainDaily(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Mode = DAILY
+	options.Puzzle = 100
+	game, err := startGame(options)
+	if err != nil {
+		t.Fatalf("Expected the daily puzzle but got %s", err)
+	}
+	solution := game.boards[0].solution
+	guess := "adept"
+	if solution == guess {
+		guess = "earth"
+	}
+	var out strings.Builder
+	if code := playPlain(game, strings.NewReader(guess+"\n"), &out); code != EXIT_ERROR {
+		t.Fatalf("Expected the input to end early but got %d", code)
+	}
+
+	// playing again continues the puzzle instead of starting over
+	game, err = startGame(options)
+	if err != nil || game.attempt != 1 {
+		t.Fatalf("Expected the daily puzzle to be restored but got %v", err)
+	}
+	out.Reset()
+	if code := playPlain(game, strings.NewReader(solution+"\n"), &out); code != EXIT_WIN {
+		t.Errorf("Expected a win but got %d", code)
+	}
+	if !strings.Contains(out.String(), strings.ToUpper(guess)+" ") || !strings.Contains(out.String(), "You win! 2/6") {
+		t.Errorf("Expected the earlier guess to be shown but got\n%s", out.String())
+	}
+	if game, _ = startGame(options); game.status != WIN {
+		t.Errorf("Expected the finished daily puzzle to stay finished")
+	}
+}
+
+func TestPlainEndOfInput(t *testing.T) {
+	game := NewTestGame("earth")
+	var out strings.Builder
+	if code := playPlain(game, strings.NewReader("adept\n"), &out); code != EXIT_ERROR {
+		t.Errorf("Expected exit code %d but got %d", EXIT_ERROR, code)
+	}
+}
+
+func TestPlainBoards(t *testing.T) {
+	game := NewTestGame("earth", "pilot")
+	var out strings.Builder
+	playPlain(game, strings.NewReader("earth\npilot\n"), &out)
+	if !strings.Contains(out.String(), "EARTH ggggg bbbyb") || !strings.Contains(out.String(), "PILOT       ggggg") {
+		t.Errorf("Expected the feedback of both boards but got\n%s", out.String())
+	}
+}
+
+func TestPlainAssist(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = ASSIST
+	game, err := NewGame(options)
+	if err != nil {
+		t.Fatalf("Expected game to be created but got %s", err)
+	}
+	var out strings.Builder
+	code := playPlain(game, strings.NewReader("adept\nadept ybyby\nearth ggggg\n"), &out)
+	if code != EXIT_WIN {
+		t.Errorf("Expected exit code %d but got %d\n%s", EXIT_WIN, code, out.String())
+	}
+	if !strings.Contains(out.String(), "Expected the guess followed by its feedback") {
+		t.Errorf("Expected a guess without feedback to be rejected but got\n%s", out.String())
+	}
+}
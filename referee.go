@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error codes sent to bots for guesses that are not accepted. Rejected
+// guesses don't count as an attempt.
+const (
+	REFEREE_INVALID_JSON = "invalid_json"
+	REFEREE_WRONG_LENGTH = "wrong_length"
+	REFEREE_INVALID_WORD = "invalid_word"
+	REFEREE_HARD_MODE    = "hard_mode"
+)
+
+// refereeMessage is a line of the protocol written by the referee. The type
+// tells which of the fields are set: "game" starts a game, "feedback"
+// answers a guess, "error" rejects one and "result" ends a game.
+type refereeMessage struct {
+	Type     string     `json:"type"`
+	Game     int        `json:"game,omitempty"`
+	Games    int        `json:"games,omitempty"`
+	Length   int        `json:"length,omitempty"`
+	Guesses  int        `json:"guesses,omitempty"`
+	Mode     string     `json:"mode,omitempty"`
+	Hard     bool       `json:"hard,omitempty"`
+	Lang     string     `json:"lang,omitempty"`
+	Alphabet string     `json:"alphabet,omitempty"` // letters a guess may use
+	Guess    string     `json:"guess,omitempty"`
+	Feedback []Feedback `json:"feedback,omitempty"`
+	Pattern  string     `json:"pattern,omitempty"`
+	Attempt  int        `json:"attempt,omitempty"`
+	Status   string     `json:"status,omitempty"`
+	Code     string     `json:"code,omitempty"`
+	Message  string     `json:"message,omitempty"`
+	Solution string     `json:"solution,omitempty"`
+}
+
+// refereeSummary is the last line written by the referee.
+type refereeSummary struct {
+	Type         string      `json:"type"` // always "summary"
+	Games        int         `json:"games"`
+	Wins         int         `json:"wins"`
+	Average      float64     `json:"average"` // guesses per win
+	Rejected     int         `json:"rejected"`
+	Distribution map[int]int `json:"distribution"`
+}
+
+// refereeGuess is a line of the protocol written by a bot.
+type refereeGuess struct {
+	Guess string `json:"guess"`
+}
+
+var statusNames = map[GameStatus]string{
+	ONGOING: "ongoing",
+	WIN:     "win",
+	LOSE:    "lose",
+}
+
+// referee plays games against a bot reading from r and writing to w. The
+// solutions are drawn with seed, so a session can be repeated exactly. The
+// summary is returned once all games are played or the input ends.
+func referee(options Options, games int, seed int64, r io.Reader, w io.Writer) (refereeSummary, error) {
+	encoder := json.NewEncoder(w)
+	scanner := bufio.NewScanner(r)
+	rng := rand.New(rand.NewSource(seed))
+	summary := refereeSummary{Type: "summary", Distribution: make(map[int]int)}
+	total := 0
+
+	for i := 1; i <= games; i++ {
+		game, err := NewGame(options)
+		if err != nil {
+			return summary, err
+		}
+		board := game.boards[0]
+		if board.adversary == nil {
+			words := board.trie.words()
+			board.solution = words[rng.Intn(len(words))]
+		}
+		err = encoder.Encode(refereeMessage{
+			Type:     "game",
+			Game:     i,
+			Games:    games,
+			Length:   game.length(),
+			Guesses:  game.options.Guesses,
+			Mode:     game.options.Mode.String(),
+			Hard:     game.options.Hard,
+			Lang:     game.language().code,
+			Alphabet: string(game.language().alphabet),
+		})
+		if err != nil {
+			return summary, err
+		}
+
+		ended := false
+		for game.status == ONGOING {
+			if !scanner.Scan() {
+				ended = true
+				break
+			}
+			reply := refereeTurn(game, scanner.Bytes())
+			if reply.Type == "error" {
+				summary.Rejected++
+			}
+			if err := encoder.Encode(reply); err != nil {
+				return summary, err
+			}
+		}
+		if game.status == ONGOING {
+			// the bot gave up
+			game.status = LOSE
+		}
+
+		summary.Games++
+		if game.status == WIN {
+			summary.Wins++
+			summary.Distribution[game.attempt]++
+			total += game.attempt
+		}
+		err = encoder.Encode(refereeMessage{
+			Type:     "result",
+			Game:     i,
+			Status:   statusNames[game.status],
+			Attempt:  game.attempt,
+			Solution: board.solution,
+		})
+		if err != nil {
+			return summary, err
+		}
+		if err := scanner.Err(); err != nil {
+			return summary, err
+		}
+		if ended {
+			break
+		}
+	}
+
+	if summary.Wins > 0 {
+		summary.Average = float64(total) / float64(summary.Wins)
+	}
+	return summary, encoder.Encode(summary)
+}
+
+// refereeTurn applies a line of a bot to game and returns the reply.
+func refereeTurn(game *Game, line []byte) refereeMessage {
+	var guess refereeGuess
+	if err := json.Unmarshal(line, &guess); err != nil {
+		return refereeMessage{Type: "error", Code: REFEREE_INVALID_JSON, Message: err.Error()}
+	}
+	word := strings.ToLower(guess.Guess)
+	board := game.boards[0]
+	if utf8.RuneCountInString(word) != game.length() {
+		return refereeMessage{Type: "error", Guess: word, Code: REFEREE_WRONG_LENGTH, Message: fmt.Sprintf("guess has to be %d letters long", game.length())}
+	}
+	if _, err := NewGuess(word, game.language(), game.length()); err != nil || !board.guessTrie.findWord(word) {
+		return refereeMessage{Type: "error", Guess: word, Code: REFEREE_INVALID_WORD, Message: fmt.Sprintf("'%s' is not a valid word", word)}
+	}
+	if err := game.guess(word); err != nil {
+		code := REFEREE_INVALID_WORD
+		var hardModeErr *HardModeError
+		if errors.As(err, &hardModeErr) {
+			code = REFEREE_HARD_MODE
+		}
+		return refereeMessage{Type: "error", Guess: word, Code: code, Message: game.message}
+	}
+
+	last := board.board[game.attempt-1]
+	feedback := make([]Feedback, len(last))
+	for i, char := range last {
+		feedback[i] = char.feedback
+	}
+	return refereeMessage{
+		Type:     "feedback",
+		Guess:    word,
+		Feedback: feedback,
+		Pattern:  last.pattern(),
+		Attempt:  game.attempt,
+		Status:   statusNames[game.status],
+	}
+}
+
+func runReferee(args []string) error {
+	options := DefaultOptions()
+	flags := flag.NewFlagSet("referee", flag.ContinueOnError)
+	flags.IntVar(&options.Length, "length", DEFAULT_WORD_LENGTH, fmt.Sprintf("number of letters per word (%d-%d)", MIN_WORD_LENGTH, MAX_WORD_LENGTH))
+	flags.IntVar(&options.Guesses, "guesses", DEFAULT_GUESSES, fmt.Sprintf("number of allowed guesses (%d-%d)", MIN_GUESSES, MAX_GUESSES))
+	flags.BoolVar(&options.Hard, "hard", false, "reject guesses that ignore the revealed hints")
+	flags.StringVar(&options.Lang, "lang", DEFAULT_LANGUAGE, fmt.Sprintf("language of the words, one of %s", strings.Join(languageCodes(), ", ")))
+	flags.Func("mode", "game mode, 'classic' or 'absurdle'", func(name string) error {
+		mode, err := parseMode(name)
+		if err == nil && mode != CLASSIC && mode != ABSURDLE {
+			err = fmt.Errorf("Error: Bots can only play 'classic' or 'absurdle'")
+		}
+		options.Mode = mode
+		return err
+	})
+	games := flags.Int("games", 1, "number of games played in sequence")
+	seed := flags.Int64("seed", 1, "seed of the solutions")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s referee [flags]\n", APP_NAME)
+		fmt.Fprintln(flags.Output(), "Plays games against a bot over JSON lines, the bot writes {\"guess\": \"crane\"} to stdin.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *games < 1 {
+		return fmt.Errorf("Error: At least one game has to be played")
+	}
+	_, err := referee(options, *games, *seed, os.Stdin, os.Stdout)
+	return err
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func readReferee(t *testing.T, output string) []refereeMessage {
+	messages := make([]refereeMessage, 0)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		var message refereeMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			t.Fatalf("Expected a JSON line but got '%s'", scanner.Text())
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func TestRefereeTurn(t *testing.T) {
+	game := NewTestGame("earth")
+	cases := map[string]string{
+		`{"guess": `:         REFEREE_INVALID_JSON,
+		`{"guess": "ear"}`:   REFEREE_WRONG_LENGTH,
+		`{"guess": "xxxxx"}`: REFEREE_INVALID_WORD,
+		`{"guess": "12345"}`: REFEREE_INVALID_WORD,
+	}
+	for line, code := range cases {
+		if reply := refereeTurn(game, []byte(line)); reply.Type != "error" || reply.Code != code {
+			t.Errorf("Expected error '%s' for %s but got %+v", code, line, reply)
+		}
+	}
+	if game.attempt != 0 {
+		t.Errorf("Expected rejected guesses not to count")
+	}
+
+	reply := refereeTurn(game, []byte(`{"guess": "ADEPT"}`))
+	if reply.Type != "feedback" || reply.Pattern != "ybyby" || reply.Attempt != 1 || reply.Status != "ongoing" {
+		t.Errorf("Expected feedback for 'adept' but got %+v", reply)
+	}
+	if len(reply.Feedback) != 5 || reply.Feedback[0] != YELLOW {
+		t.Errorf("Expected the feedback of every letter but got %v", reply.Feedback)
+	}
+
+	game.setHardMode(true)
+	game.boards[0].hard = true
+	if reply := refereeTurn(game, []byte(`{"guess": "quick"}`)); reply.Code != REFEREE_HARD_MODE {
+		t.Errorf("Expected error '%s' but got %+v", REFEREE_HARD_MODE, reply)
+	}
+}
+
+func TestReferee(t *testing.T) {
+	var out strings.Builder
+	input := strings.Repeat(`{"guess": "adept"}`+"\n", 12)
+	summary, err := referee(DefaultOptions(), 2, 7, strings.NewReader(input), &out)
+	if err != nil {
+		t.Fatalf("Expected the games to be refereed but got %s", err)
+	}
+	if summary.Games != 2 {
+		t.Errorf("Expected 2 games but got %+v", summary)
+	}
+
+	messages := readReferee(t, out.String())
+	if messages[0].Type != "game" || messages[0].Length != 5 || messages[0].Guesses != 6 || messages[0].Mode != "classic" || messages[0].Lang != "en" {
+		t.Errorf("Expected the game to be described first but got %+v", messages[0])
+	}
+	results := make([]string, 0)
+	for _, message := range messages {
+		if message.Type == "result" {
+			results = append(results, message.Solution)
+		}
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results but got %v", results)
+	}
+	if !strings.Contains(out.String(), `"type":"summary"`) {
+		t.Errorf("Expected a summary at the end but got\n%s", out.String())
+	}
+
+	// the same seed draws the same solutions
+	var again strings.Builder
+	referee(DefaultOptions(), 2, 7, strings.NewReader(input), &again)
+	if again.String() != out.String() {
+		t.Errorf("Expected the same session for the same seed")
+	}
+}
+
+func TestRefereeEndOfInput(t *testing.T) {
+	var out strings.Builder
+	summary, err := referee(DefaultOptions(), 3, 1, strings.NewReader(`{"guess": "adept"}`+"\n"), &out)
+	if err != nil {
+		t.Fatalf("Expected the session to end cleanly but got %s", err)
+	}
+	if summary.Games != 1 || summary.Wins != 0 {
+		t.Errorf("Expected the unfinished game to be lost and no more games to be started but got %+v", summary)
+	}
+}
+
+func TestRefereeGerman(t *testing.T) {
+	options := DefaultOptions()
+	options.Lang = "de"
+	var out strings.Builder
+	input := strings.Repeat(`{"guess": "grüße"}`+"\n", 6)
+	if _, err := referee(options, 1, 3, strings.NewReader(input), &out); err != nil {
+		t.Fatalf("Expected a German game to be refereed but got %s", err)
+	}
+
+	messages := readReferee(t, out.String())
+	if messages[0].Lang != "de" || !strings.ContainsRune(messages[0].Alphabet, 'ß') {
+		t.Errorf("Expected the language and its alphabet in the game but got %+v", messages[0])
+	}
+	if messages[1].Type != "feedback" || messages[1].Guess != "grüße" || len(messages[1].Feedback) != 5 {
+		t.Errorf("Expected feedback for a guess with umlauts but got %+v", messages[1])
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+const (
+	REMAINING_WIDTH  = 24
+	REMAINING_HEIGHT = 10
+)
+
+// Remaining keeps the solutions still consistent with every board of a game
+// and a scrollable list of them that can be filtered.
+type Remaining struct {
+	game      *Game
+	attempt   int
+	words     [][]string // board -> consistent words
+	shown     bool
+	filtering bool
+	filter    textinput.Model
+	viewport  viewport.Model
+}
+
+func NewRemaining() Remaining {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "filter"
+	filter.CharLimit = MAX_WORD_LENGTH
+	filter.Width = REMAINING_WIDTH - 2
+	return Remaining{
+		filter:   filter,
+		viewport: viewport.New(REMAINING_WIDTH, REMAINING_HEIGHT),
+	}
+}
+
+// refresh collects the consistent words again once a guess was made.
+func (r *Remaining) refresh(game *Game) {
+	if r.game == game && r.attempt == game.attempt && r.words != nil {
+		return
+	}
+	r.game, r.attempt = game, game.attempt
+	r.words = make([][]string, len(game.boards))
+	for i, board := range game.boards {
+		if board.status == ONGOING {
+			r.words[i] = board.consistentWords()
+		}
+	}
+	r.viewport.GotoTop()
+	r.update()
+}
+
+// update fills the list with the words matching the filter.
+func (r *Remaining) update() {
+	filter := strings.ToLower(r.filter.Value())
+	var s strings.Builder
+	for i, words := range r.words {
+		if r.game.boards[i].status != ONGOING {
+			continue
+		}
+		if len(r.words) > 1 {
+			s.WriteString(fmt.Sprintf("Board %d:\n", i+1))
+		}
+		for _, word := range words {
+			if strings.Contains(word, filter) {
+				s.WriteString(fmt.Sprintf("  %s\n", strings.ToUpper(word)))
+			}
+		}
+	}
+	r.viewport.SetContent(strings.TrimSuffix(s.String(), "\n"))
+}
+
+func (r Remaining) count() string {
+	counts := make([]string, 0, len(r.words))
+	for i, words := range r.words {
+		if r.game.boards[i].status == ONGOING {
+			counts = append(counts, fmt.Sprint(len(words)))
+		} else {
+			counts = append(counts, "-")
+		}
+	}
+	return strings.Join(counts, " ")
+}
+
+func (r Remaining) View() string {
+	if r.game == nil || r.game.status != ONGOING {
+		return ""
+	}
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("Remaining: %s\n", r.count()))
+	if r.shown {
+		if r.filtering || r.filter.Value() != "" {
+			s.WriteString(r.filter.View() + "\n")
+		}
+		s.WriteString(lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(colorGrey).Render(r.viewport.View()))
+	}
+	return helpTextStyle.Copy().MarginBottom(1).Render(s.String())
+}
+
+// handleKey handles the keys of the list while it is shown. Keys it does not
+// use are reported back so they reach the game.
+func (r *Remaining) handleKey(msg tea.KeyMsg) (bool, tea.Cmd) {
+	var cmd tea.Cmd
+	if r.filtering {
+		switch msg.String() {
+		case "ctrl+c":
+			return false, cmd
+		case tea.KeyEsc.String(), tea.KeyEnter.String():
+			r.filtering = false
+			r.filter.Blur()
+		default:
+			r.filter, cmd = r.filter.Update(msg)
+			r.viewport.GotoTop()
+			r.update()
+		}
+		return true, cmd
+	}
+
+	switch msg.String() {
+	case "/":
+		r.filtering = true
+		return true, r.filter.Focus()
+	case tea.KeyEsc.String():
+		r.filter.Reset()
+		r.update()
+		return true, cmd
+	case tea.KeyUp.String(), tea.KeyDown.String(), tea.KeyPgUp.String(), tea.KeyPgDown.String():
+		r.viewport, cmd = r.viewport.Update(msg)
+		return true, cmd
+	}
+	return false, cmd
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRemainingRefresh(t *testing.T) {
+	game := NewTestGame("earth", "pilot")
+	remaining := NewRemaining()
+	remaining.refresh(game)
+	if total := len(remaining.words[0]); total != len(game.boards[0].trie.words()) {
+		t.Errorf("Expected every solution to remain before the first guess but got %d", total)
+	}
+
+	game.guess("adept")
+	remaining.refresh(game)
+	for i, board := range game.boards {
+		expected := board.consistentWords()
+		if len(remaining.words[i]) != len(expected) {
+			t.Errorf("Expected %d words on board %d but got %d", len(expected), i+1, len(remaining.words[i]))
+		}
+	}
+	expected := fmt.Sprintf("%d %d", len(remaining.words[0]), len(remaining.words[1]))
+	if count := remaining.count(); count != expected {
+		t.Errorf("Expected the count '%s' but got '%s'", expected, count)
+	}
+
+	game.guess("earth")
+	remaining.refresh(game)
+	if count := remaining.count(); !strings.HasPrefix(count, "- ") {
+		t.Errorf("Expected the solved board to be left out but got '%s'", count)
+	}
+}
+
+func TestRemainingFilter(t *testing.T) {
+	game := NewTestGame("earth")
+	game.guess("adept")
+	remaining := NewRemaining()
+	remaining.shown = true
+	remaining.refresh(game)
+
+	if handled, _ := remaining.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}); handled {
+		t.Errorf("Expected letters to reach the game while not filtering")
+	}
+	if handled, _ := remaining.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")}); !handled || !remaining.filtering {
+		t.Fatalf("Expected '/' to start filtering")
+	}
+	for _, char := range "rth" {
+		remaining.handleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{char}})
+	}
+	remaining.handleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if remaining.filtering || remaining.filter.Value() != "rth" {
+		t.Fatalf("Expected the filter 'rth' to be set but got '%s'", remaining.filter.Value())
+	}
+
+	remaining.viewport.Height = 1000
+	view := remaining.viewport.View()
+	if !strings.Contains(view, "EARTH") {
+		t.Errorf("Expected 'EARTH' to match the filter but got\n%s", view)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(view), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.Contains(line, "RTH") {
+			t.Errorf("Expected only words matching the filter but got '%s'", line)
+		}
+	}
+
+	remaining.handleKey(tea.KeyMsg{Type: tea.KeyEsc})
+	if remaining.filter.Value() != "" {
+		t.Errorf("Expected escape to clear the filter")
+	}
+}
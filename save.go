@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+)
+
+const (
+	SAVE_FILE    = "save.json"
+	SAVE_VERSION = 1
+)
+
+// savedGame is the on disk representation of an unfinished game. Solutions
+// are stored as challenge codes so they can't be read from the file. Only the
+// guesses are needed to restore a game, the feedback is kept to verify that
+// replaying them leads to the same board.
+type savedGame struct {
+	Version     int        `json:"version"`
+	Options     Options    `json:"options"`
+	Game        Options    `json:"game"`
+	Solutions   []string   `json:"solutions"`
+	Guesses     []string   `json:"guesses"`
+	Feedback    [][]string `json:"feedback"` // board -> guess -> pattern
+	Help        bool       `json:"help"`
+	Hints       bool       `json:"hints"`
+	Suggestions bool       `json:"suggestions"`
+	Stats       bool       `json:"stats"`
+}
+
+func newSavedGame(m model) savedGame {
+	game := m.game.options
+	game.Solution = ""
+	options := m.options
+	options.Solution = ""
+
+	saved := savedGame{
+		Version:     SAVE_VERSION,
+		Options:     options,
+		Game:        game,
+		Solutions:   make([]string, len(m.game.boards)),
+		Guesses:     m.game.history,
+		Feedback:    make([][]string, len(m.game.boards)),
+		Help:        m.help,
+		Hints:       m.hints,
+		Suggestions: m.suggestions,
+		Stats:       m.stats,
+	}
+	for i, board := range m.game.boards {
+		if board.solution != "" && board.adversary == nil {
+			saved.Solutions[i] = encodeChallenge(board.solution, game)
+		}
+		saved.Feedback[i] = board.patterns()
+	}
+	return saved
+}
+
+// restore creates the saved game by replaying its guesses.
+func (s savedGame) restore() (*Game, error) {
+	if s.Version != SAVE_VERSION {
+		return nil, fmt.Errorf("Error: Unsupported save version %d", s.Version)
+	}
+	if len(s.Solutions) != s.Game.Boards {
+		return nil, fmt.Errorf("Error: Corrupted save")
+	}
+
+	solutions := make([]string, len(s.Solutions))
+	for i, code := range s.Solutions {
+		if code == "" {
+			continue
+		}
+		options, err := decodeChallenge(code)
+		if err != nil {
+			return nil, err
+		}
+		solutions[i] = options.Solution
+	}
+
+	options := s.Game
+	if options.Mode == CHALLENGE {
+		options.Solution = solutions[0]
+	}
+	game, err := NewGame(options)
+	if err != nil {
+		return nil, err
+	}
+	for i, board := range game.boards {
+		if board.adversary == nil {
+			board.solution = solutions[i]
+		}
+	}
+	if options.Mode == ASSIST {
+		// there is no solution, the entered feedback is all there is
+		if len(s.Feedback) != 1 {
+			return nil, fmt.Errorf("Error: Corrupted save")
+		}
+		err = game.replayFeedback(s.Guesses, s.Feedback[0])
+	} else {
+		err = game.replay(s.Guesses)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i, board := range game.boards {
+		patterns := board.patterns()
+		if len(s.Feedback) != len(game.boards) || len(s.Feedback[i]) != len(patterns) {
+			return nil, fmt.Errorf("Error: Corrupted save")
+		}
+		for j := range patterns {
+			if patterns[j] != s.Feedback[i][j] {
+				return nil, fmt.Errorf("Error: Corrupted save")
+			}
+		}
+	}
+	return game, nil
+}
+
+// saveGame stores the game of m so it can be resumed on the next launch.
+// Finished games are not worth resuming and timed games can't be paused, any
+// previous save is removed.
+func saveGame(m model) error {
+	if m.game.status != ONGOING || m.game.attempt == 0 || m.game.options.timed() {
+		return removeData(SAVE_FILE)
+	}
+	return writeData(SAVE_FILE, newSavedGame(m))
+}
+
+// loadGame returns the saved game, or nil if there is none.
+func loadGame() (*savedGame, error) {
+	var saved savedGame
+	if err := readData(SAVE_FILE, &saved); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &saved, nil
+}
+
+// GAME_FLAGS shape a new game, a resumed game keeps its own options.
+var GAME_FLAGS = []string{"boards", "date", "guesses", "hard", "lang", "length", "mode", "time"}
+
+// gameFlags returns the flags of GAME_FLAGS that were set.
+func gameFlags(flags *flag.FlagSet) []string {
+	set := make([]string, 0)
+	flags.Visit(func(f *flag.Flag) {
+		if slices.Contains(GAME_FLAGS, f.Name) {
+			set = append(set, "--"+f.Name)
+		}
+	})
+	return set
+}
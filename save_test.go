@@ -0,0 +1,139 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func NewTestModel(t *testing.T, options Options) model {
+	m, err := NewModel(options)
+	if err != nil {
+		t.Fatalf("Expected model to be created but got %s", err)
+	}
+	return m
+}
+
+func TestSaveAndResume(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dir)
+
+	m := NewTestModel(t, DefaultOptions())
+	m.game.boards[0].solution = "earth"
+	m.hints = true
+	for _, word := range []string{"adept", "baste"} {
+		if err := m.game.guess(word); err != nil {
+			t.Fatalf("Expected guess to be successful but got %s", err)
+		}
+	}
+	if err := saveGame(m); err != nil {
+		t.Fatalf("Expected game to be saved but got %s", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, APP_NAME, SAVE_FILE))
+	if err != nil {
+		t.Fatalf("Expected save file to exist but got %s", err)
+	}
+	if strings.Contains(string(data), "earth") {
+		t.Errorf("Expected the solution not to be stored in plaintext")
+	}
+
+	saved, err := loadGame()
+	if err != nil || saved == nil {
+		t.Fatalf("Expected saved game to be loaded but got %v", err)
+	}
+	resumed := NewTestModel(t, DefaultOptions())
+	if err := resumed.resume(*saved); err != nil {
+		t.Fatalf("Expected game to be resumed but got %s", err)
+	}
+	board := resumed.game.boards[0]
+	if board.solution != "earth" || board.attempt != 2 || !resumed.hints {
+		t.Errorf("Expected the saved game to be resumed")
+	}
+	if board.minCount[board.lang.idx('e')] != 1 || board.assign[3] != board.lang.idx('t') {
+		t.Errorf("Expected constraints to be rebuilt by replaying the guesses")
+	}
+	if resumed.inputs[1][0].Value() != "b" {
+		t.Errorf("Expected the guesses to be shown on the board")
+	}
+}
+
+func TestSaveFinishedGame(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	m := NewTestModel(t, DefaultOptions())
+	m.game.guess("adept")
+	if err := saveGame(m); err != nil {
+		t.Fatalf("Expected game to be saved but got %s", err)
+	}
+
+	m.game.guess(m.game.boards[0].solution)
+	if err := saveGame(m); err != nil {
+		t.Fatalf("Expected save to be removed but got %s", err)
+	}
+	if saved, err := loadGame(); err != nil || saved != nil {
+		t.Errorf("Expected no saved game after the game ended")
+	}
+}
+
+func TestRestoreAbsurdleAndCorruption(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Mode = ABSURDLE
+	m := NewTestModel(t, options)
+	m.game.guess("adept")
+	m.game.guess("crane")
+
+	saved := newSavedGame(m)
+	game, err := saved.restore()
+	if err != nil {
+		t.Fatalf("Expected absurdle game to be restored but got %s", err)
+	}
+	if game.boards[0].adversary.size() != m.game.boards[0].adversary.size() {
+		t.Errorf("Expected replaying to narrow the candidates the same way")
+	}
+
+	saved.Feedback[0][0] = "ggggg"
+	if _, err := saved.restore(); err == nil {
+		t.Errorf("Expected an error for a save with mismatching feedback")
+	}
+}
+
+func TestSaveAndResumeAssist(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Mode = ASSIST
+	m := NewTestModel(t, options)
+	if err := m.game.enter("adept", []Feedback{YELLOW, GREY, YELLOW, GREY, YELLOW}); err != nil {
+		t.Fatalf("Expected feedback to be entered but got %s", err)
+	}
+	if err := saveGame(m); err != nil {
+		t.Fatalf("Expected game to be saved but got %s", err)
+	}
+
+	saved, err := loadGame()
+	if err != nil || saved == nil {
+		t.Fatalf("Expected saved game to be loaded but got %v", err)
+	}
+	game, err := saved.restore()
+	if err != nil {
+		t.Fatalf("Expected game to be restored but got %s", err)
+	}
+	if pattern := game.boards[0].board[0].pattern(); pattern != "ybyby" {
+		t.Errorf("Expected the entered feedback to be restored but got '%s'", pattern)
+	}
+}
+
+func TestGameFlags(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Int("length", 5, "")
+	flags.String("mode", "classic", "")
+	flags.Bool("hints", false, "")
+	if err := flags.Parse([]string{"--mode", "daily", "--hints", "--length", "6"}); err != nil {
+		t.Fatal(err)
+	}
+	if set := gameFlags(flags); strings.Join(set, " ") != "--length --mode" {
+		t.Errorf("Expected the flags shaping the game but got %v", set)
+	}
+}
@@ -0,0 +1,394 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SERVER_ADDR = "localhost:8080"
+	SERVER_TTL  = 30 * time.Minute
+)
+
+// storedGame is a game hosted by the server. The engine is not safe for
+// concurrent use, every access to the game has to hold the lock.
+type storedGame struct {
+	mu      sync.Mutex
+	id      string
+	game    *Game
+	touched time.Time
+}
+
+// GameStore holds the games of the server. Games are forgotten once they
+// have not been accessed for the ttl.
+type GameStore struct {
+	mu    sync.Mutex
+	games map[string]*storedGame
+	daily sync.Mutex // guards the daily record shared by the games
+	ttl   time.Duration
+	now   func() time.Time
+}
+
+func NewGameStore(ttl time.Duration) *GameStore {
+	return &GameStore{
+		games: make(map[string]*storedGame),
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+func newGameID() (string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
+func (s *GameStore) create(options Options) (*storedGame, error) {
+	s.daily.Lock()
+	game, err := startGame(options)
+	s.daily.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	id, err := newGameID()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	stored := &storedGame{id: id, game: game, touched: s.now()}
+	s.games[id] = stored
+	return stored, nil
+}
+
+// get returns the game with the given id and keeps it from expiring.
+func (s *GameStore) get(id string) (*storedGame, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	stored, ok := s.games[id]
+	if ok {
+		stored.touched = s.now()
+	}
+	return stored, ok
+}
+
+func (s *GameStore) remove(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.games[id]
+	delete(s.games, id)
+	return ok
+}
+
+// expire removes the games that were not accessed within the ttl, the lock
+// has to be held.
+func (s *GameStore) expire() {
+	now := s.now()
+	for id, stored := range s.games {
+		if now.Sub(stored.touched) > s.ttl {
+			delete(s.games, id)
+		}
+	}
+}
+
+func (s *GameStore) size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.games)
+}
+
+// apiRow is a guess on a board with the feedback of every letter.
+type apiRow struct {
+	Guess    string     `json:"guess"`
+	Pattern  string     `json:"pattern"`
+	Feedback []Feedback `json:"feedback"`
+}
+
+type apiBoard struct {
+	Status   string   `json:"status"`
+	Rows     []apiRow `json:"rows"`
+	Solution string   `json:"solution,omitempty"` // once the game is over
+}
+
+type apiGame struct {
+	ID      string     `json:"id"`
+	Options Options    `json:"options"`
+	Attempt int        `json:"attempt"`
+	Status  string     `json:"status"`
+	Guesses []string   `json:"guesses"`
+	Boards  []apiBoard `json:"boards"`
+}
+
+type apiError struct {
+	Error   string `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+type apiCreate struct {
+	Options
+	Challenge string `json:"challenge,omitempty"` // plays the challenge code
+}
+
+type apiGuess struct {
+	Guess    string `json:"guess"`
+	Feedback string `json:"feedback,omitempty"` // assist only, e.g. "gybgg"
+}
+
+type apiSuggestions struct {
+	Solver      string            `json:"solver"`
+	Unit        string            `json:"unit,omitempty"`
+	Remaining   []int             `json:"remaining"` // per board, 0 once solved
+	Suggestions []solveSuggestion `json:"suggestions"`
+}
+
+func newAPIGame(stored *storedGame) apiGame {
+	game := stored.game
+	options := game.options
+	options.Solution = ""
+	state := apiGame{
+		ID:      stored.id,
+		Options: options,
+		Attempt: game.attempt,
+		Status:  statusNames[game.status],
+		Guesses: append(make([]string, 0, len(game.history)), game.history...),
+		Boards:  make([]apiBoard, len(game.boards)),
+	}
+	for i, board := range game.boards {
+		state.Boards[i] = apiBoard{Status: statusNames[board.status], Rows: make([]apiRow, 0, board.attempt)}
+		for _, guess := range board.board[:board.attempt] {
+			row := apiRow{Guess: guess.word(), Pattern: guess.pattern(), Feedback: make([]Feedback, len(guess))}
+			for j, char := range guess {
+				row.Feedback[j] = char.feedback
+			}
+			state.Boards[i].Rows = append(state.Boards[i].Rows, row)
+		}
+		if game.status != ONGOING {
+			state.Boards[i].Solution = board.solution
+		}
+	}
+	return state
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error, message string) {
+	writeJSON(w, status, apiError{Error: err.Error(), Message: message})
+}
+
+// Server exposes the games of a store over a REST API:
+//
+//	POST   /games                    create a game, the body holds its options
+//	GET    /games/{id}               state of a game
+//	DELETE /games/{id}               forget a game
+//	POST   /games/{id}/guesses       guess a word, {"guess": "crane"}
+//	GET    /games/{id}/suggestions   ranked guesses, ?solver=NAME&n=N
+type Server struct {
+	store *GameStore
+	mux   *http.ServeMux
+}
+
+func NewServer(store *GameStore) *Server {
+	s := &Server{store: store, mux: http.NewServeMux()}
+	s.mux.HandleFunc("POST /games", s.handleCreate)
+	s.mux.HandleFunc("GET /games/{id}", s.withGame(s.handleState))
+	s.mux.HandleFunc("DELETE /games/{id}", s.handleDelete)
+	s.mux.HandleFunc("POST /games/{id}/guesses", s.withGame(s.handleGuess))
+	s.mux.HandleFunc("GET /games/{id}/suggestions", s.withGame(s.handleSuggestions))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// withGame looks up the game of the request and holds its lock while the
+// handler runs.
+func (s *Server) withGame(handler func(http.ResponseWriter, *http.Request, *storedGame)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		stored, ok := s.store.get(r.PathValue("id"))
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("Error: Unknown game"), "the game does not exist or has expired")
+			return
+		}
+		stored.mu.Lock()
+		defer stored.mu.Unlock()
+		handler(w, r, stored)
+	}
+}
+
+func (s *Server) handleCreate(w http.ResponseWriter, r *http.Request) {
+	request := apiCreate{Options: DefaultOptions()}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("Error: Invalid request"), err.Error())
+			return
+		}
+	}
+	options := request.Options
+	options.Solution = ""
+	if request.Challenge != "" {
+		decoded, err := decodeChallenge(request.Challenge)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err, "")
+			return
+		}
+		options = decoded
+	} else if options.Mode == CHALLENGE {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("Error: Challenges need a code"), "")
+		return
+	}
+	if options.timed() {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("Error: Timed modes are only played in the terminal"), "")
+		return
+	}
+	if options.Mode == DAILY {
+		today, err := dailyNumber(time.Now())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err, "")
+			return
+		}
+		if options.Puzzle == 0 {
+			options.Puzzle = today
+		}
+		if options.Puzzle > today {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("Error: Daily puzzle #%d is not out yet", options.Puzzle), "")
+			return
+		}
+	}
+
+	stored, err := s.store.create(options)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "")
+		return
+	}
+	stored.mu.Lock()
+	defer stored.mu.Unlock()
+	writeJSON(w, http.StatusCreated, newAPIGame(stored))
+}
+
+func (s *Server) handleState(w http.ResponseWriter, r *http.Request, stored *storedGame) {
+	writeJSON(w, http.StatusOK, newAPIGame(stored))
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	if !s.store.remove(r.PathValue("id")) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("Error: Unknown game"), "")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleGuess(w http.ResponseWriter, r *http.Request, stored *storedGame) {
+	var request apiGuess
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("Error: Invalid request"), err.Error())
+		return
+	}
+	game := stored.game
+	word := strings.ToLower(request.Guess)
+
+	var err error
+	if game.options.Mode == ASSIST {
+		var feedback []Feedback
+		if feedback, err = parsePattern(request.Feedback, game.length()); err == nil {
+			err = game.enter(word, feedback)
+		}
+	} else {
+		err = game.guess(word)
+	}
+	if err != nil {
+		status := http.StatusUnprocessableEntity
+		if game.status != ONGOING {
+			status = http.StatusConflict
+		}
+		var hardModeErr *HardModeError
+		if errors.As(err, &hardModeErr) {
+			writeError(w, status, err, hardModeErr.message)
+			return
+		}
+		writeError(w, status, err, game.message)
+		return
+	}
+	if game.options.Mode == DAILY {
+		s.store.daily.Lock()
+		err := saveDaily(game)
+		s.store.daily.Unlock()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err, "")
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, newAPIGame(stored))
+}
+
+func (s *Server) handleSuggestions(w http.ResponseWriter, r *http.Request, stored *storedGame) {
+	name := r.URL.Query().Get("solver")
+	if name == "" {
+		name = SOLVERS[0]
+	}
+	solver, err := NewSolver(name, time.Now().UnixNano())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err, "")
+		return
+	}
+	n := SUGGESTIONS
+	if value := r.URL.Query().Get("n"); value != "" {
+		if n, err = strconv.Atoi(value); err != nil || n < 1 || n > MAX_SUGGESTIONS {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("Error: Number of suggestions has to be between 1 and %d", MAX_SUGGESTIONS), "")
+			return
+		}
+	}
+
+	game := stored.game
+	response := apiSuggestions{
+		Solver:      solver.name(),
+		Unit:        solver.unit(),
+		Remaining:   make([]int, len(game.boards)),
+		Suggestions: make([]solveSuggestion, 0, n),
+	}
+	for i, board := range game.boards {
+		if board.status == ONGOING {
+			response.Remaining[i] = len(board.consistentWords())
+		}
+	}
+	for _, guess := range game.rankGuesses(solver, n) {
+		response.Suggestions = append(response.Suggestions, solveSuggestion{Word: guess.word, Score: guess.score, Candidate: guess.candidate})
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", SERVER_ADDR, "address to listen on")
+	ttl := flags.Duration("ttl", SERVER_TTL, "time after which inactive games are forgotten")
+	flags.BoolVar(&cachePatterns, "cache-patterns", false, "keep the feedback patterns of the solvers in the data directory")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s serve [flags]\n", APP_NAME)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	server := NewServer(NewGameStore(*ttl))
+	fmt.Printf("Listening on http://%s\n", *addr)
+	return http.ListenAndServe(*addr, server)
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func request(t *testing.T, server http.Handler, method string, path string, body string, v any) int {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	server.ServeHTTP(w, r)
+	if v != nil {
+		if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+			t.Fatalf("Expected JSON from %s %s but got '%s'", method, path, w.Body.String())
+		}
+	}
+	return w.Code
+}
+
+func TestServerGame(t *testing.T) {
+	store := NewGameStore(time.Minute)
+	server := NewServer(store)
+
+	var created apiGame
+	if code := request(t, server, "POST", "/games", `{"guesses": 4}`, &created); code != http.StatusCreated {
+		t.Fatalf("Expected the game to be created but got %d", code)
+	}
+	if created.ID == "" || created.Options.Guesses != 4 || created.Options.Length != 5 || created.Status != "ongoing" {
+		t.Errorf("Expected a new game with 4 guesses but got %+v", created)
+	}
+	stored, _ := store.get(created.ID)
+	stored.game.boards[0].solution = "earth"
+
+	var rejected apiError
+	if code := request(t, server, "POST", "/games/"+created.ID+"/guesses", `{"guess": "xxxxx"}`, &rejected); code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected an invalid word to be rejected but got %d", code)
+	}
+	if rejected.Message != "'xxxxx' is not a valid word" {
+		t.Errorf("Expected the reason of the rejection but got %+v", rejected)
+	}
+
+	var state apiGame
+	request(t, server, "POST", "/games/"+created.ID+"/guesses", `{"guess": "adept"}`, &state)
+	if code := request(t, server, "GET", "/games/"+created.ID, "", &state); code != http.StatusOK {
+		t.Fatalf("Expected the state of the game but got %d", code)
+	}
+	if state.Attempt != 1 || len(state.Boards[0].Rows) != 1 || state.Boards[0].Rows[0].Pattern != "ybyby" {
+		t.Errorf("Expected the guess on the board but got %+v", state)
+	}
+	if state.Boards[0].Rows[0].Feedback[0] != YELLOW || state.Boards[0].Solution != "" {
+		t.Errorf("Expected the feedback without the solution but got %+v", state.Boards[0])
+	}
+
+	var suggestions apiSuggestions
+	if code := request(t, server, "GET", "/games/"+created.ID+"/suggestions?solver=backtrack&n=2", "", &suggestions); code != http.StatusOK {
+		t.Fatalf("Expected suggestions but got %d", code)
+	}
+	if suggestions.Solver != "backtrack" || len(suggestions.Suggestions) != 2 || suggestions.Remaining[0] == 0 {
+		t.Errorf("Expected 2 suggestions but got %+v", suggestions)
+	}
+	if code := request(t, server, "GET", "/games/"+created.ID+"/suggestions?solver=oracle", "", nil); code != http.StatusBadRequest {
+		t.Errorf("Expected an unknown solver to be rejected but got %d", code)
+	}
+	for _, n := range []string{"0", "101", "2000000000000"} {
+		if code := request(t, server, "GET", "/games/"+created.ID+"/suggestions?n="+n, "", nil); code != http.StatusBadRequest {
+			t.Errorf("Expected n=%s to be rejected but got %d", n, code)
+		}
+	}
+
+	request(t, server, "POST", "/games/"+created.ID+"/guesses", `{"guess": "earth"}`, &state)
+	if state.Status != "win" || state.Boards[0].Solution != "earth" {
+		t.Errorf("Expected the game to be won but got %+v", state)
+	}
+	if code := request(t, server, "POST", "/games/"+created.ID+"/guesses", `{"guess": "earth"}`, nil); code != http.StatusConflict {
+		t.Errorf("Expected guesses after the game to conflict but got %d", code)
+	}
+
+	if code := request(t, server, "DELETE", "/games/"+created.ID, "", nil); code != http.StatusNoContent {
+		t.Errorf("Expected the game to be deleted but got %d", code)
+	}
+	if code := request(t, server, "GET", "/games/"+created.ID, "", nil); code != http.StatusNotFound {
+		t.Errorf("Expected a deleted game to be gone but got %d", code)
+	}
+}
+
+func TestServerCreateInvalid(t *testing.T) {
+	server := NewServer(NewGameStore(time.Minute))
+	for _, body := range []string{`{"length": 12}`, `{"mode": "nope"}`, `{"mode": "challenge"}`, `{`} {
+		if code := request(t, server, "POST", "/games", body, nil); code != http.StatusBadRequest {
+			t.Errorf("Expected %s to be rejected but got %d", body, code)
+		}
+	}
+}
+
+func TestServerDaily(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	server := NewServer(NewGameStore(time.Minute))
+	today, _ := dailyNumber(time.Now())
+	var created apiGame
+	if code := request(t, server, "POST", "/games", `{"mode": "daily"}`, &created); code != http.StatusCreated {
+		t.Fatalf("Expected the daily puzzle to be created but got %d", code)
+	}
+	if created.Options.Puzzle != today {
+		t.Errorf("Expected today's puzzle #%d but got %d", today, created.Options.Puzzle)
+	}
+	request(t, server, "POST", "/games/"+created.ID+"/guesses", `{"guess": "adept"}`, nil)
+	var restarted apiGame
+	request(t, server, "POST", "/games", `{"mode": "daily"}`, &restarted)
+	if restarted.Attempt != 1 || len(restarted.Guesses) != 1 || restarted.Guesses[0] != "adept" {
+		t.Errorf("Expected the guesses on the daily puzzle to be restored but got %+v", restarted)
+	}
+	body := fmt.Sprintf(`{"mode": "daily", "puzzle": %d}`, today+1)
+	if code := request(t, server, "POST", "/games", body, nil); code != http.StatusBadRequest {
+		t.Errorf("Expected a future puzzle to be rejected but got %d", code)
+	}
+}
+
+func TestServerAssist(t *testing.T) {
+	server := NewServer(NewGameStore(time.Minute))
+	var created apiGame
+	request(t, server, "POST", "/games", `{"mode": "assist"}`, &created)
+	var state apiGame
+	if code := request(t, server, "POST", "/games/"+created.ID+"/guesses", `{"guess": "adept", "feedback": "ybyby"}`, &state); code != http.StatusOK {
+		t.Fatalf("Expected the feedback to be entered but got %d", code)
+	}
+	if state.Boards[0].Rows[0].Pattern != "ybyby" {
+		t.Errorf("Expected the entered feedback but got %+v", state.Boards[0])
+	}
+}
+
+func TestGameStoreExpire(t *testing.T) {
+	store := NewGameStore(time.Minute)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	old, _ := store.create(DefaultOptions())
+	now = now.Add(45 * time.Second)
+	recent, _ := store.create(DefaultOptions())
+	now = now.Add(30 * time.Second)
+
+	if _, ok := store.get(old.id); ok {
+		t.Errorf("Expected the inactive game to expire")
+	}
+	if _, ok := store.get(recent.id); !ok {
+		t.Errorf("Expected the recent game to be kept")
+	}
+}
+
+func TestServerConcurrentGuesses(t *testing.T) {
+	store := NewGameStore(time.Minute)
+	server := NewServer(store)
+	var created apiGame
+	request(t, server, "POST", "/games", `{"guesses": 20}`, &created)
+	stored, _ := store.get(created.ID)
+	stored.game.boards[0].solution = "earth"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := httptest.NewRequest("POST", "/games/"+created.ID+"/guesses", strings.NewReader(`{"guess": "adept"}`))
+			server.ServeHTTP(httptest.NewRecorder(), r)
+		}()
+	}
+	wg.Wait()
+
+	var state apiGame
+	request(t, server, "GET", "/games/"+created.ID, "", &state)
+	if state.Attempt != 10 || len(state.Guesses) != 10 {
+		t.Errorf("Expected all 10 guesses to be applied but got %d", state.Attempt)
+	}
+	if store.size() != 1 {
+		t.Errorf("Expected a single game in the store but got %d", store.size())
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	osc52 "github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type ShareStyle int
+
+const (
+	EMOJI ShareStyle = iota
+	CONTRAST
+	ASCII
+)
+
+var shareStyleNames = map[ShareStyle]string{
+	EMOJI:    "emoji",
+	CONTRAST: "contrast",
+	ASCII:    "ascii",
+}
+
+func parseShareStyle(name string) (ShareStyle, error) {
+	for style, style_name := range shareStyleNames {
+		if style_name == name {
+			return style, nil
+		}
+	}
+	return EMOJI, fmt.Errorf("Error: Unknown share style '%s'", name)
+}
+
+// shareSymbols map feedback to the symbols of a share grid. The contrast
+// variant matches the high contrast colours of the original game, the ascii
+// variant survives chat tools that mangle emoji.
+var shareSymbols = map[ShareStyle]map[Feedback]string{
+	EMOJI:    {TBD: "  ", GREY: "⬛", YELLOW: "🟨", GREEN: "🟩"},
+	CONTRAST: {TBD: "  ", GREY: "⬛", YELLOW: "🟦", GREEN: "🟧"},
+	ASCII:    {TBD: " ", GREY: ".", YELLOW: "?", GREEN: "#"},
+}
+
+func shareScore(board *Wordle, guesses int) string {
+	if board.status == WIN {
+		return fmt.Sprintf("%d/%d", board.attempt, guesses)
+	}
+	return fmt.Sprintf("X/%d", guesses)
+}
+
+// shareText returns the familiar result grid of a finished game, e.g.
+//
+//	Wordle-TUI 123 4/6
+//
+//	⬛🟨⬛⬛⬛
+//	...
+//
+// Several boards are shown side by side.
+func shareText(game *Game, style ShareStyle) string {
+	var s strings.Builder
+	s.WriteString("Wordle-TUI")
+	switch game.options.Mode {
+	case DAILY:
+		s.WriteString(fmt.Sprintf(" %d", game.options.Puzzle))
+	case CLASSIC:
+	default:
+		s.WriteString(" " + strings.ToUpper(game.options.Mode.String()[:1]) + game.options.Mode.String()[1:])
+	}
+	for _, board := range game.boards {
+		s.WriteString(" " + shareScore(board, game.options.Guesses))
+	}
+	if game.options.Hard {
+		s.WriteString("*")
+	}
+	s.WriteString("\n")
+
+	symbols := shareSymbols[style]
+	columns := boardColumns(len(game.boards))
+	for first := 0; first < len(game.boards); first += columns {
+		s.WriteString("\n")
+		for row := 0; row < game.attempt; row++ {
+			line := make([]string, 0, columns)
+			for _, board := range game.boards[first : first+columns] {
+				var tiles strings.Builder
+				for col := 0; col < board.length; col++ {
+					feedback := TBD
+					if row < board.attempt {
+						feedback = board.board[row][col].feedback
+					}
+					tiles.WriteString(symbols[feedback])
+				}
+				line = append(line, tiles.String())
+			}
+			s.WriteString(strings.TrimRight(strings.Join(line, " "), " ") + "\n")
+		}
+	}
+	return strings.TrimRight(s.String(), "\n")
+}
+
+// copyToClipboard copies text to the system clipboard with an OSC52 escape
+// sequence, which also works over SSH.
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		seq := osc52.New(text)
+		if os.Getenv("TMUX") != "" {
+			seq = seq.Tmux()
+		} else if strings.HasPrefix(os.Getenv("TERM"), "screen") {
+			seq = seq.Screen()
+		}
+		seq.WriteTo(os.Stderr)
+		return nil
+	}
+}
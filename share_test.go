@@ -0,0 +1,58 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShareText(t *testing.T) {
+	game := NewTestGame("earth")
+	game.guess("adept")
+	game.guess("earth")
+
+	expected := "Wordle-TUI 2/6\n\n🟨⬛🟨⬛🟨\n🟩🟩🟩🟩🟩"
+	if text := shareText(game, EMOJI); text != expected {
+		t.Errorf("Expected share text\n%s\nbut got\n%s", expected, text)
+	}
+
+	expected = "Wordle-TUI 2/6\n\n?.?.?\n#####"
+	if text := shareText(game, ASCII); text != expected {
+		t.Errorf("Expected share text\n%s\nbut got\n%s", expected, text)
+	}
+
+	if text := shareText(game, CONTRAST); !strings.Contains(text, "🟧🟧🟧🟧🟧") || !strings.Contains(text, "🟦") {
+		t.Errorf("Expected high contrast colours but got\n%s", text)
+	}
+}
+
+func TestShareTextHeader(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = DAILY
+	options.Puzzle = 123
+	options.Hard = true
+	game, err := NewGame(options)
+	if err != nil {
+		t.Fatalf("Expected game to be created but got %s", err)
+	}
+	for i := 0; i < options.Guesses && game.status == ONGOING; i++ {
+		word := "adept"
+		if game.boards[0].solution == word {
+			word = "earth"
+		}
+		game.guess(word)
+	}
+	if header := strings.Split(shareText(game, EMOJI), "\n")[0]; header != "Wordle-TUI 123 X/6*" {
+		t.Errorf("Expected header 'Wordle-TUI 123 X/6*' but got '%s'", header)
+	}
+}
+
+func TestShareTextBoards(t *testing.T) {
+	game := NewTestGame("earth", "adept")
+	game.guess("earth")
+	game.guess("adept")
+
+	expected := "Wordle-TUI 1/7 2/7\n\n##### ??.?.\n      #####"
+	if text := shareText(game, ASCII); text != expected {
+		t.Errorf("Expected share text\n%s\nbut got\n%s", expected, text)
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// solveSuggestion is a ranked guess as printed by the solve command.
+type solveSuggestion struct {
+	Word      string  `json:"word"`
+	Score     float64 `json:"score"`
+	Candidate bool    `json:"candidate"`
+}
+
+// solveResult is the output of the solve command.
+type solveResult struct {
+	Solver      string            `json:"solver"`
+	Unit        string            `json:"unit,omitempty"`
+	Solved      bool              `json:"solved"`
+	Remaining   int               `json:"remaining"`
+	Candidates  []string          `json:"candidates"`
+	Suggestions []solveSuggestion `json:"suggestions"`
+}
+
+// parseSolveArg splits an argument like "crane:gybgg" into the guess and its
+// feedback.
+func parseSolveArg(arg string) (string, []Feedback, error) {
+	word, pattern, ok := strings.Cut(strings.ToLower(arg), ":")
+	if !ok {
+		return "", nil, fmt.Errorf("Error: Invalid argument '%s', expected WORD:PATTERN like crane:gybgg", arg)
+	}
+	feedback, err := parsePattern(pattern, utf8.RuneCountInString(word))
+	if err != nil {
+		return "", nil, err
+	}
+	return word, feedback, nil
+}
+
+// solve enters the guesses with their feedback into a game without a
+// solution and collects what is left.
+func solve(args []string, options Options, solver Solver, n int) (solveResult, error) {
+	options.Mode = ASSIST
+	options.Boards = 1
+	options.Guesses = MAX_GUESSES
+	if len(args) > MAX_GUESSES {
+		return solveResult{}, fmt.Errorf("Error: At most %d guesses are supported", MAX_GUESSES)
+	}
+	if len(args) > 0 {
+		word, _, err := parseSolveArg(args[0])
+		if err != nil {
+			return solveResult{}, err
+		}
+		options.Length = utf8.RuneCountInString(word)
+	}
+
+	game, err := NewGame(options)
+	if err != nil {
+		return solveResult{}, err
+	}
+	for _, arg := range args {
+		word, feedback, err := parseSolveArg(arg)
+		if err != nil {
+			return solveResult{}, err
+		}
+		if err := game.enter(word, feedback); err != nil {
+			return solveResult{}, fmt.Errorf("%s: %s", err, game.message)
+		}
+	}
+
+	result := solveResult{
+		Solver:      solver.name(),
+		Unit:        solver.unit(),
+		Solved:      game.status == WIN,
+		Candidates:  make([]string, 0),
+		Suggestions: make([]solveSuggestion, 0, n),
+	}
+	if game.status != ONGOING {
+		return result, nil
+	}
+	result.Candidates = game.boards[0].consistentWords()
+	result.Remaining = len(result.Candidates)
+	for _, guess := range game.rankGuesses(solver, n) {
+		result.Suggestions = append(result.Suggestions, solveSuggestion{Word: guess.word, Score: guess.score, Candidate: guess.candidate})
+	}
+	return result, nil
+}
+
+func (r solveResult) write(w io.Writer, limit int) {
+	if r.Solved {
+		fmt.Fprintln(w, "Solved")
+		return
+	}
+	fmt.Fprintf(w, "Remaining: %d\n", r.Remaining)
+	candidates := r.Candidates
+	if limit > 0 && len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	for i := 0; i < len(candidates); i += 8 {
+		fmt.Fprintf(w, "  %s\n", strings.Join(candidates[i:min(i+8, len(candidates))], " "))
+	}
+	if len(candidates) < len(r.Candidates) {
+		fmt.Fprintf(w, "  ... %d more\n", len(r.Candidates)-len(candidates))
+	}
+
+	fmt.Fprintf(w, "Try (%s):\n", r.Solver)
+	for _, suggestion := range r.Suggestions {
+		if r.Unit == "" {
+			fmt.Fprintf(w, "  %s\n", suggestion.Word)
+		} else {
+			fmt.Fprintf(w, "  %s %5.2f %s\n", suggestion.Word, suggestion.Score, r.Unit)
+		}
+	}
+}
+
+func runSolve(args []string) error {
+	options := DefaultOptions()
+	flags := flag.NewFlagSet("solve", flag.ContinueOnError)
+	solverName := flags.String("solver", SOLVERS[0], fmt.Sprintf("strategy of the suggestions, one of %s", strings.Join(SOLVERS, ", ")))
+	flags.IntVar(&options.Length, "length", DEFAULT_WORD_LENGTH, "number of letters per word if no guess is given")
+	flags.BoolVar(&options.Hard, "hard", false, "only suggest guesses allowed in hard mode")
+	flags.StringVar(&options.Lang, "lang", DEFAULT_LANGUAGE, fmt.Sprintf("language of the words, one of %s", strings.Join(languageCodes(), ", ")))
+	flags.BoolVar(&cachePatterns, "cache-patterns", false, "keep the feedback patterns of the solvers in the data directory")
+	suggestions := flags.Int("suggestions", SUGGESTIONS, "number of suggestions")
+	limit := flags.Int("candidates", 40, "number of remaining candidates printed, 0 prints all of them")
+	asJSON := flags.Bool("json", false, "print the result as JSON, always with every candidate")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s solve [flags] [WORD:PATTERN ...]\n", APP_NAME)
+		fmt.Fprintln(flags.Output(), "PATTERN has a letter per tile: g for green, y for yellow and b for grey, e.g. crane:gybgg")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *suggestions < 0 || *suggestions > MAX_SUGGESTIONS {
+		return fmt.Errorf("Error: Number of suggestions has to be between 0 and %d", MAX_SUGGESTIONS)
+	}
+	solver, err := NewSolver(*solverName, time.Now().UnixNano())
+	if err != nil {
+		return err
+	}
+	result, err := solve(flags.Args(), options, solver, *suggestions)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	result.write(os.Stdout, *limit)
+	return nil
+}
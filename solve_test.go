@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseSolveArg(t *testing.T) {
+	word, feedback, err := parseSolveArg("CRANE:gybgg")
+	if err != nil {
+		t.Fatalf("Expected argument to be parsed but got %s", err)
+	}
+	if word != "crane" || len(feedback) != 5 || feedback[1] != YELLOW {
+		t.Errorf("Expected 'crane' with its feedback but got '%s' %v", word, feedback)
+	}
+	for _, arg := range []string{"crane", "crane:gyb", "crane:gybgx"} {
+		if _, _, err := parseSolveArg(arg); err == nil {
+			t.Errorf("Expected '%s' to be rejected", arg)
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	solver, _ := NewSolver("backtrack", 1)
+	args := make([]string, 0)
+	for _, word := range []string{"adept"} {
+		pattern := ""
+		for _, feedback := range score(word, "earth") {
+			pattern += string(feedbackChars[feedback])
+		}
+		args = append(args, word+":"+pattern)
+	}
+	result, err := solve(args, DefaultOptions(), solver, 3)
+	if err != nil {
+		t.Fatalf("Expected a result but got %s", err)
+	}
+	found := false
+	for _, word := range result.Candidates {
+		found = found || word == "earth"
+	}
+	if !found || result.Remaining != len(result.Candidates) {
+		t.Errorf("Expected 'earth' among the candidates but got %v", result.Candidates)
+	}
+	if len(result.Suggestions) == 0 || result.Suggestions[0].Word != result.Candidates[0] {
+		t.Errorf("Expected the first candidate to be suggested but got %+v", result.Suggestions)
+	}
+
+	var s strings.Builder
+	result.write(&s, 1)
+	if !strings.Contains(s.String(), "Remaining: ") || !strings.Contains(s.String(), "more") {
+		t.Errorf("Expected the remaining words to be cut off but got\n%s", s.String())
+	}
+}
+
+func TestSolveLength(t *testing.T) {
+	solver, _ := NewSolver("backtrack", 1)
+	result, err := solve([]string{"absent:bbbbbb"}, DefaultOptions(), solver, 1)
+	if err != nil {
+		t.Fatalf("Expected 6 letter words to be solved but got %s", err)
+	}
+	for _, word := range result.Candidates {
+		if len(word) != 6 {
+			t.Errorf("Expected only 6 letter candidates but got '%s'", word)
+		}
+	}
+
+	result, err = solve([]string{"earth:ggggg"}, DefaultOptions(), solver, 1)
+	if err != nil || !result.Solved {
+		t.Errorf("Expected the puzzle to be solved but got %+v, %v", result, err)
+	}
+	if _, err := solve([]string{"xxxxx:bbbbb"}, DefaultOptions(), solver, 1); err == nil {
+		t.Errorf("Expected unknown words to be rejected")
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SUGGESTIONS is the number of guesses ranked by the suggestion panel.
+const SUGGESTIONS = 5
+
+// MAX_SUGGESTIONS is the most guesses that can be asked for at once.
+const MAX_SUGGESTIONS = 100
+
+// RankedGuess is a guess with the score a solver gave it.
+type RankedGuess struct {
+	word      string
+	score     float64
+	candidate bool
+}
+
+// SolverState is what a solver gets to see of a game: the words that may be
+// guessed and the solutions still possible on every unsolved board.
+type SolverState struct {
+	lang       *Language
+	length     int
+	guesses    []string
+	candidates [][]string
+	fresh      bool // nothing has been guessed yet
+}
+
+// A Solver ranks the guesses for a game state, best first.
+type Solver interface {
+	name() string
+	unit() string // of the scores, empty if they are meaningless
+	rank(state SolverState, n int) []RankedGuess
+}
+
+var SOLVERS = []string{"entropy", "minimax", "expected", "backtrack", "random"}
+
+// NewSolver returns the solver called name. Solvers that pick at random are
+// seeded with seed.
+func NewSolver(name string, seed int64) (Solver, error) {
+	switch name {
+	case "entropy":
+		return &metricSolver{solverName: name, solverUnit: "bits", metric: entropy, maximize: true}, nil
+	case "minimax":
+		return &metricSolver{solverName: name, solverUnit: "worst", metric: worstBucket, maximize: false}, nil
+	case "expected":
+		return &metricSolver{solverName: name, solverUnit: "left", metric: expectedSize, maximize: false}, nil
+	case "backtrack":
+		return backtrackSolver{}, nil
+	case "random":
+		return &randomSolver{rng: rand.New(rand.NewSource(seed))}, nil
+	}
+	return nil, fmt.Errorf("Error: Unknown solver '%s', expected one of %s", name, strings.Join(SOLVERS, ", "))
+}
+
+// nextSolver returns the solver following solver in SOLVERS.
+func nextSolver(solver Solver) Solver {
+	for i, name := range SOLVERS {
+		if name == solver.name() {
+			next, _ := NewSolver(SOLVERS[(i+1)%len(SOLVERS)], rand.Int63())
+			return next
+		}
+	}
+	next, _ := NewSolver(SOLVERS[0], rand.Int63())
+	return next
+}
+
+// backtrackSolver suggests the first words consistent with the board, in the
+// order the backtracking search over the trie finds them.
+type backtrackSolver struct{}
+
+func (s backtrackSolver) name() string { return "backtrack" }
+func (s backtrackSolver) unit() string { return "" }
+
+func (s backtrackSolver) rank(state SolverState, n int) []RankedGuess {
+	candidates := firstCandidates(state)
+	ranked := make([]RankedGuess, 0, min(n, len(candidates)))
+	for _, word := range candidates {
+		if len(ranked) == n {
+			break
+		}
+		ranked = append(ranked, RankedGuess{word: word, candidate: true})
+	}
+	return ranked
+}
+
+// firstCandidates returns the candidates of the board closest to being
+// solved.
+func firstCandidates(state SolverState) []string {
+	var first []string
+	for _, candidates := range state.candidates {
+		if first == nil || len(candidates) < len(first) {
+			first = candidates
+		}
+	}
+	return first
+}
+
+// randomSolver suggests random words consistent with the board.
+type randomSolver struct {
+	rng *rand.Rand
+}
+
+func (s *randomSolver) name() string { return "random" }
+func (s *randomSolver) unit() string { return "" }
+
+func (s *randomSolver) rank(state SolverState, n int) []RankedGuess {
+	candidates := firstCandidates(state)
+	ranked := make([]RankedGuess, 0, min(n, len(candidates)))
+	for _, i := range s.rng.Perm(len(candidates)) {
+		if len(ranked) == n {
+			break
+		}
+		ranked = append(ranked, RankedGuess{word: candidates[i], candidate: true})
+	}
+	return ranked
+}
+
+// metricSolver scores every allowed guess with a metric summed over all
+// unsolved boards. Guesses that could be the solution win ties.
+type metricSolver struct {
+	solverName string
+	solverUnit string
+	metric     func(patterns []int, solved int, counts []int) float64
+	maximize   bool
+}
+
+func (s *metricSolver) name() string { return s.solverName }
+func (s *metricSolver) unit() string { return s.solverUnit }
+
+type openerKey struct {
+	solver     string
+	dictionary dictionaryKey
+}
+
+var (
+	openerMu    sync.Mutex
+	openerCache = make(map[openerKey][]RankedGuess)
+)
+
+func (s *metricSolver) rank(state SolverState, n int) []RankedGuess {
+	if !state.fresh || len(state.candidates) == 0 {
+		return s.rankAll(state, n)
+	}
+
+	// every board starts from the full list, so the opener is the same for
+	// all of them and only computed once
+	key := openerKey{solver: s.solverName, dictionary: dictionaryKey{lang: state.lang.code, length: state.length}}
+	openerMu.Lock()
+	opener, ok := openerCache[key]
+	if !ok || len(opener) < n {
+		single := state
+		single.candidates = state.candidates[:1]
+		opener = s.rankAll(single, n)
+		openerCache[key] = opener
+	}
+	openerMu.Unlock()
+
+	ranked := make([]RankedGuess, 0, min(n, len(opener)))
+	for _, guess := range opener[:min(n, len(opener))] {
+		guess.score *= float64(len(state.candidates))
+		ranked = append(ranked, guess)
+	}
+	return ranked
+}
+
+// rankAll scores every guess, spreading the work over all CPU cores.
+func (s *metricSolver) rankAll(state SolverState, n int) []RankedGuess {
+	// the chance to win right away, summed over the boards
+	chance := make(map[string]float64)
+	for _, candidates := range state.candidates {
+		for _, candidate := range candidates {
+			chance[candidate] += 1 / float64(len(candidates))
+		}
+	}
+
+	// without a table every pattern is scored directly
+	table, _ := patternTable(state.lang, state.length)
+	solved := patternCount(state.length) - 1
+
+	ranked := make([]RankedGuess, len(state.guesses))
+	workers := runtime.NumCPU()
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			counts := make([]int, patternCount(state.length))
+			var patterns []int
+			for i := worker; i < len(state.guesses); i += workers {
+				score := 0.0
+				for _, candidates := range state.candidates {
+					patterns = table.patterns(state.guesses[i], candidates, patterns)
+					score += s.metric(patterns, solved, counts)
+				}
+				_, candidate := chance[state.guesses[i]]
+				ranked[i] = RankedGuess{word: state.guesses[i], score: score, candidate: candidate}
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if s.maximize {
+			if x, y := a.score+chance[a.word], b.score+chance[b.word]; x != y {
+				return x > y
+			}
+		} else if a.score != b.score {
+			return a.score < b.score
+		}
+		if a.candidate != b.candidate {
+			return a.candidate
+		}
+		return a.word < b.word
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	return ranked
+}
+
+// solverState collects the state of the unsolved boards for a solver. Only
+// guesses allowed in hard mode are considered when it is enabled.
+func (g *Game) solverState() SolverState {
+	unsolved := g.unsolved()
+	state := SolverState{
+		lang:       g.language(),
+		length:     g.length(),
+		guesses:    make([]string, 0),
+		candidates: make([][]string, len(unsolved)),
+		fresh:      true,
+	}
+	if len(unsolved) == 0 {
+		return state
+	}
+
+	for i, board := range unsolved {
+		state.candidates[i] = board.consistentWords()
+		state.fresh = state.fresh && board.attempt == 0 && board.adversary == nil
+	}
+
+	words := unsolved[0].guessTrie.words()
+	if !g.options.Hard {
+		state.guesses = words
+		return state
+	}
+	for _, word := range words {
+		guess, err := NewGuess(word, g.language(), g.length())
+		if err != nil {
+			continue
+		}
+		allowed := true
+		for _, board := range unsolved {
+			if board.hard && board.validateHard(guess) != nil {
+				allowed = false
+				break
+			}
+		}
+		if allowed {
+			state.guesses = append(state.guesses, word)
+		}
+	}
+	return state
+}
+
+// rankTask prepares ranking the guesses for the current state of the game.
+// The returned function only works on a copy of the state, so it can run in
+// the background while the game goes on.
+func (g *Game) rankTask(solver Solver, n int) func() []RankedGuess {
+	state := g.solverState()
+	return func() []RankedGuess {
+		if len(state.candidates) == 0 {
+			return nil
+		}
+		return solver.rank(state, n)
+	}
+}
+
+func (g *Game) rankGuesses(solver Solver, n int) []RankedGuess {
+	return g.rankTask(solver, n)()
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewSolver(t *testing.T) {
+	for _, name := range SOLVERS {
+		solver, err := NewSolver(name, 1)
+		if err != nil {
+			t.Fatalf("Expected solver '%s' but got %s", name, err)
+		}
+		if solver.name() != name {
+			t.Errorf("Expected solver '%s' but got '%s'", name, solver.name())
+		}
+	}
+	if _, err := NewSolver("oracle", 1); err == nil {
+		t.Errorf("Expected unknown solver to fail")
+	}
+}
+
+func TestNextSolver(t *testing.T) {
+	solver, _ := NewSolver(SOLVERS[0], 1)
+	for i := 1; i <= len(SOLVERS); i++ {
+		solver = nextSolver(solver)
+		if expected := SOLVERS[i%len(SOLVERS)]; solver.name() != expected {
+			t.Errorf("Expected solver '%s' but got '%s'", expected, solver.name())
+		}
+	}
+}
+
+func TestSolversRank(t *testing.T) {
+	state := SolverState{
+		lang:       LANGUAGES[DEFAULT_LANGUAGE],
+		length:     5,
+		guesses:    []string{"quick", "hater", "earth"},
+		candidates: [][]string{{"earth", "heart", "hater"}},
+	}
+	for _, name := range SOLVERS {
+		solver, _ := NewSolver(name, 1)
+		ranked := solver.rank(state, 2)
+		if len(ranked) != 2 {
+			t.Fatalf("Expected 2 guesses from '%s' but got %d", name, len(ranked))
+		}
+		for _, guess := range ranked {
+			if guess.word == "quick" {
+				t.Errorf("Expected '%s' to never suggest 'quick' but got %+v", name, ranked)
+			}
+		}
+	}
+}
+
+func TestBacktrackSolver(t *testing.T) {
+	game := NewTestGame("earth")
+	game.guess("adept")
+	solver, _ := NewSolver("backtrack", 1)
+	ranked := game.rankGuesses(solver, 1)
+	if len(ranked) != 1 {
+		t.Fatalf("Expected a suggestion")
+	}
+	if expected := game.boards[0].consistentWords()[0]; ranked[0].word != expected {
+		t.Errorf("Expected '%s' but got '%s'", expected, ranked[0].word)
+	}
+}
+
+func TestRandomSolverSeed(t *testing.T) {
+	game := NewTestGame("earth")
+	game.guess("adept")
+	a, _ := NewSolver("random", 7)
+	b, _ := NewSolver("random", 7)
+	x, y := game.rankGuesses(a, 3), game.rankGuesses(b, 3)
+	for i := range x {
+		if x[i].word != y[i].word {
+			t.Errorf("Expected the same suggestions for the same seed but got %+v and %+v", x, y)
+		}
+		guess, _ := NewGuess(x[i].word, game.language(), 5)
+		if !game.boards[0].validateFull(guess) {
+			t.Errorf("Expected '%s' to be consistent with the board", x[i].word)
+		}
+	}
+}
+
+func TestGameRankGuesses(t *testing.T) {
+	game := NewTestGame("earth")
+	game.guess("adept")
+	game.guess("baste")
+	solver, _ := NewSolver("entropy", 1)
+	ranked := game.rankGuesses(solver, SUGGESTIONS)
+	if len(ranked) == 0 {
+		t.Fatalf("Expected ranked guesses")
+	}
+	for i := 1; i < len(ranked); i++ {
+		if ranked[i].score > ranked[i-1].score+1 {
+			t.Errorf("Expected guesses to be ranked by information but got %+v", ranked)
+		}
+	}
+}
+
+func TestGameRankGuessesMinimize(t *testing.T) {
+	game := NewTestGame("earth")
+	game.guess("adept")
+	for _, name := range []string{"minimax", "expected"} {
+		solver, _ := NewSolver(name, 1)
+		ranked := game.rankGuesses(solver, SUGGESTIONS)
+		for i := 1; i < len(ranked); i++ {
+			if ranked[i].score < ranked[i-1].score {
+				t.Errorf("Expected '%s' to rank the lowest score first but got %+v", name, ranked)
+			}
+		}
+	}
+}
+
+func TestGameRankGuessesBoards(t *testing.T) {
+	game := NewTestGame("earth", "pilot")
+	game.guess("adept")
+	game.guess("baste")
+	solver, _ := NewSolver("entropy", 1)
+	ranked := game.rankGuesses(solver, 1)
+	if len(ranked) != 1 {
+		t.Fatalf("Expected a ranked guess")
+	}
+	// 'earth' is the only candidate left on the first board
+	if candidates := game.boards[0].consistentWords(); len(candidates) == 1 && !ranked[0].candidate {
+		t.Errorf("Expected a solution to be preferred but got %+v", ranked[0])
+	}
+}
+
+func TestSolversRankMany(t *testing.T) {
+	game := NewTestGame("earth")
+	for _, name := range SOLVERS {
+		solver, _ := NewSolver(name, 1)
+		if ranked := game.rankGuesses(solver, math.MaxInt32); len(ranked) == 0 {
+			t.Errorf("Expected '%s' to rank the guesses it has", name)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+const (
+	STATS_FILE    = "stats.json"
+	STATS_VERSION = 2
+)
+
+// Record holds the statistics of a group of games.
+type Record struct {
+	Played        int         `json:"played"`
+	Wins          int         `json:"wins"`
+	CurrentStreak int         `json:"current_streak"`
+	MaxStreak     int         `json:"max_streak"`
+	Distribution  map[int]int `json:"distribution"` // attempt -> wins
+}
+
+func NewRecord() *Record {
+	return &Record{Distribution: make(map[int]int)}
+}
+
+func (r *Record) add(win bool, attempts int) {
+	r.Played++
+	if !win {
+		r.CurrentStreak = 0
+		return
+	}
+	r.Wins++
+	r.CurrentStreak++
+	if r.CurrentStreak > r.MaxStreak {
+		r.MaxStreak = r.CurrentStreak
+	}
+	if r.Distribution == nil {
+		r.Distribution = make(map[int]int)
+	}
+	r.Distribution[attempts]++
+}
+
+func (r *Record) winPercentage() int {
+	if r.Played == 0 {
+		return 0
+	}
+	return r.Wins * 100 / r.Played
+}
+
+// SpeedrunRecord holds the statistics of speedruns, every run counts as one
+// played.
+type SpeedrunRecord struct {
+	Runs   int `json:"runs"`
+	Solved int `json:"solved"`
+	Failed int `json:"failed"`
+	Best   int `json:"best"` // most puzzles solved in a run
+	Last   int `json:"last"`
+}
+
+func (r *SpeedrunRecord) add(solved int, failed int) {
+	r.Runs++
+	r.Solved += solved
+	r.Failed += failed
+	r.Last = solved
+	if solved > r.Best {
+		r.Best = solved
+	}
+}
+
+// Statistics are stored as JSON in the data directory. Fields are only ever
+// added, older files are upgraded by migrate when they are loaded.
+type Statistics struct {
+	Version   int                        `json:"version"`
+	Total     *Record                    `json:"total"`
+	Modes     map[string]*Record         `json:"modes"`
+	Speedruns map[string]*SpeedrunRecord `json:"speedruns"` // since version 2
+}
+
+func NewStatistics() *Statistics {
+	return &Statistics{
+		Version:   STATS_VERSION,
+		Total:     NewRecord(),
+		Modes:     make(map[string]*Record),
+		Speedruns: make(map[string]*SpeedrunRecord),
+	}
+}
+
+func (s *Statistics) migrate() error {
+	if s.Version > STATS_VERSION {
+		return fmt.Errorf("Error: Statistics were written by a newer version (%d)", s.Version)
+	}
+	if s.Total == nil {
+		s.Total = NewRecord()
+	}
+	if s.Modes == nil {
+		s.Modes = make(map[string]*Record)
+	}
+	if s.Speedruns == nil {
+		s.Speedruns = make(map[string]*SpeedrunRecord)
+	}
+	s.Version = STATS_VERSION
+	return nil
+}
+
+// statsKey groups games by mode and variant, e.g. "classic", "daily",
+// "classic 4x6" for four boards with six letter words or "countdown 3m0s".
+func statsKey(options Options) string {
+	key := options.Mode.String()
+	if options.Boards > 1 || options.Length != DEFAULT_WORD_LENGTH {
+		key += fmt.Sprintf(" %dx%d", options.Boards, options.Length)
+	}
+	if options.timed() {
+		key += fmt.Sprintf(" %s", options.Limit)
+	}
+	if options.Lang != "" && options.Lang != DEFAULT_LANGUAGE {
+		key += " " + options.Lang
+	}
+	return key
+}
+
+func (s *Statistics) mode(options Options) *Record {
+	key := statsKey(options)
+	if _, ok := s.Modes[key]; !ok {
+		s.Modes[key] = NewRecord()
+	}
+	return s.Modes[key]
+}
+
+func (s *Statistics) add(game *Game) {
+	if game.options.Mode == ASSIST {
+		// the game was played elsewhere
+		return
+	}
+	if game.options.Mode == SPEEDRUN {
+		// whole runs are recorded by addSpeedrun
+		return
+	}
+	win := game.status == WIN
+	s.Total.add(win, game.attempt)
+	s.mode(game.options).add(win, game.attempt)
+}
+
+func (s *Statistics) addSpeedrun(options Options, solved int, failed int) {
+	key := statsKey(options)
+	if _, ok := s.Speedruns[key]; !ok {
+		s.Speedruns[key] = &SpeedrunRecord{}
+	}
+	s.Speedruns[key].add(solved, failed)
+}
+
+func loadStatistics() (*Statistics, error) {
+	stats := NewStatistics()
+	if err := readData(STATS_FILE, stats); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err := stats.migrate(); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// recordGame adds a finished game to the statistics on disk. The file is read
+// again right before writing, so games finished in another terminal are not
+// lost.
+func recordGame(game *Game) (*Statistics, error) {
+	stats, err := loadStatistics()
+	if err != nil {
+		return nil, err
+	}
+	stats.add(game)
+	if err := writeData(STATS_FILE, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// recordSpeedrun adds a finished speedrun to the statistics on disk, like
+// recordGame.
+func recordSpeedrun(options Options, solved int, failed int) (*Statistics, error) {
+	stats, err := loadStatistics()
+	if err != nil {
+		return nil, err
+	}
+	stats.addSpeedrun(options, solved, failed)
+	if err := writeData(STATS_FILE, stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+const STATS_BAR_WIDTH = 24
+
+// StatisticsView renders a record like the original game: the totals on top
+// and a histogram of the number of guesses needed to win below. The bar of
+// the last game is highlighted.
+func StatisticsView(title string, record *Record, guesses int, highlight int) string {
+	numbers := []string{
+		fmt.Sprint(record.Played),
+		fmt.Sprint(record.winPercentage()),
+		fmt.Sprint(record.CurrentStreak),
+		fmt.Sprint(record.MaxStreak),
+	}
+	labels := []string{"Played", "Win %", "Current", "Max"}
+	columns := make([]string, len(numbers))
+	for i := range numbers {
+		columns[i] = lipgloss.NewStyle().MarginRight(2).Render(lipgloss.JoinVertical(
+			lipgloss.Center,
+			lipgloss.NewStyle().Bold(true).Render(numbers[i]),
+			helpTextStyle.Render(labels[i]),
+		))
+	}
+
+	max := 1
+	attempts := make([]int, 0, guesses)
+	for attempt := 1; attempt <= guesses; attempt++ {
+		attempts = append(attempts, attempt)
+	}
+	for attempt, count := range record.Distribution {
+		if count > max {
+			max = count
+		}
+		if attempt > guesses {
+			attempts = append(attempts, attempt)
+		}
+	}
+	sort.Ints(attempts)
+
+	bars := make([]string, 0, len(attempts))
+	for _, attempt := range attempts {
+		count := record.Distribution[attempt]
+		style := greyInputStyle
+		if attempt == highlight {
+			style = greenInputStyle
+		}
+		width := 1 + count*(STATS_BAR_WIDTH-1)/max
+		bar := style.Copy().Padding(0, 0).Width(width).Align(lipgloss.Right).Render(fmt.Sprint(count))
+		bars = append(bars, fmt.Sprintf("%2d %s", attempt, bar))
+	}
+
+	return lipgloss.NewStyle().MarginBottom(2).Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render(strings.ToUpper(title)),
+		lipgloss.NewStyle().MarginBottom(1).Render(lipgloss.JoinHorizontal(lipgloss.Top, columns...)),
+		titleStyle.Render("GUESS DISTRIBUTION"),
+		lipgloss.JoinVertical(lipgloss.Left, bars...),
+	))
+}
+
+// SpeedrunView renders the record of a speedrun variant.
+func SpeedrunView(title string, record *SpeedrunRecord) string {
+	average := 0.0
+	if record.Runs > 0 {
+		average = float64(record.Solved) / float64(record.Runs)
+	}
+	numbers := []string{
+		fmt.Sprint(record.Runs),
+		fmt.Sprint(record.Last),
+		fmt.Sprint(record.Best),
+		fmt.Sprintf("%.1f", average),
+	}
+	labels := []string{"Runs", "Last", "Best", "Average"}
+	columns := make([]string, len(numbers))
+	for i := range numbers {
+		columns[i] = lipgloss.NewStyle().MarginRight(2).Render(lipgloss.JoinVertical(
+			lipgloss.Center,
+			lipgloss.NewStyle().Bold(true).Render(numbers[i]),
+			helpTextStyle.Render(labels[i]),
+		))
+	}
+	return lipgloss.NewStyle().MarginBottom(2).Render(lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render(strings.ToUpper(title)),
+		lipgloss.JoinHorizontal(lipgloss.Top, columns...),
+	))
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAdd(t *testing.T) {
+	record := NewRecord()
+	record.add(true, 3)
+	record.add(true, 4)
+	record.add(false, 6)
+	record.add(true, 3)
+
+	if record.Played != 4 || record.Wins != 3 {
+		t.Errorf("Expected 4 played and 3 wins but got %d and %d", record.Played, record.Wins)
+	}
+	if record.CurrentStreak != 1 || record.MaxStreak != 2 {
+		t.Errorf("Expected streaks 1 and 2 but got %d and %d", record.CurrentStreak, record.MaxStreak)
+	}
+	if record.Distribution[3] != 2 || record.Distribution[4] != 1 || record.Distribution[6] != 0 {
+		t.Errorf("Expected wins to be counted by attempt but got %v", record.Distribution)
+	}
+	if record.winPercentage() != 75 {
+		t.Errorf("Expected 75%% wins but got %d", record.winPercentage())
+	}
+}
+
+func TestRecordGame(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	game := NewTestGame("earth")
+	if err := game.guess("adept"); err != nil {
+		t.Fatalf("Expected guess to be successful but got %s", err)
+	}
+	if err := game.guess("earth"); err != nil {
+		t.Fatalf("Expected guess to be successful but got %s", err)
+	}
+	if _, err := recordGame(game); err != nil {
+		t.Fatalf("Expected game to be recorded but got %s", err)
+	}
+
+	stats, err := loadStatistics()
+	if err != nil {
+		t.Fatalf("Expected statistics to be loaded but got %s", err)
+	}
+	if stats.Total.Wins != 1 || stats.Modes["classic"].Distribution[2] != 1 {
+		t.Errorf("Expected the win in 2 guesses to be recorded but got %+v", stats.Total)
+	}
+}
+
+func TestLoadStatisticsVersions(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", dir)
+	path := filepath.Join(dir, APP_NAME, STATS_FILE)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	// an older file without per mode records and an unknown field
+	old := `{"total": {"played": 2, "wins": 1, "distribution": {"4": 1}}, "unknown": true}`
+	if err := os.WriteFile(path, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := loadStatistics()
+	if err != nil {
+		t.Fatalf("Expected old statistics to be loaded but got %s", err)
+	}
+	if stats.Version != STATS_VERSION || stats.Total.Played != 2 || stats.Modes == nil {
+		t.Errorf("Expected old statistics to be migrated but got %+v", stats)
+	}
+
+	newer := `{"version": 99}`
+	if err := os.WriteFile(path, []byte(newer), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadStatistics(); err == nil {
+		t.Errorf("Expected an error for statistics of a newer version")
+	}
+}
+
+func TestStatisticsView(t *testing.T) {
+	record := NewRecord()
+	record.add(true, 2)
+	record.add(true, 8)
+	view := StatisticsView("Statistics", record, 6, 2)
+	for _, expected := range []string{"STATISTICS", "GUESS DISTRIBUTION", " 6 ", " 8 "} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("Expected statistics view to contain '%s'", expected)
+		}
+	}
+}
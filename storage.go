@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const APP_NAME = "wordle-tui"
+
+// dataDir returns the directory for persistent data as specified by the XDG
+// base directory specification.
+func dataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, APP_NAME), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", APP_NAME), nil
+}
+
+// readData decodes the JSON file name in the data directory into v. A missing
+// file is reported with an error satisfying errors.Is(err, os.ErrNotExist).
+func readData(name string, v any) error {
+	data, err := readFile(name)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeData encodes v as JSON into the file name in the data directory.
+func writeData(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(name, data)
+}
+
+// readFile returns the contents of the file name in the data directory.
+func readFile(name string) ([]byte, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Join(dir, name))
+}
+
+// writeFile writes data into the file name in the data directory. The file
+// is written to a temporary file first and then renamed, so readers never see
+// a partially written file.
+func writeFile(name string, data []byte) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
+
+// removeData deletes the file name from the data directory if it exists.
+func removeData(name string) error {
+	dir, err := dataDir()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
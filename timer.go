@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	lipgloss "github.com/charmbracelet/lipgloss"
+)
+
+const (
+	COUNTDOWN_LIMIT = 3 * time.Minute
+	SPEEDRUN_LIMIT  = 5 * time.Minute
+	TIMER_TICK      = time.Second
+	TIMER_WARNING   = 10 * time.Second
+	TIMER_GRACE     = time.Second // keys are ignored this long after a timeout
+)
+
+// defaultLimit returns the time limit of a timed mode.
+func defaultLimit(mode Mode) time.Duration {
+	if mode == SPEEDRUN {
+		return SPEEDRUN_LIMIT
+	}
+	return COUNTDOWN_LIMIT
+}
+
+// Timer is the clock of the countdown and speedrun modes. It starts with the
+// first letter typed and keeps running across the puzzles of a speedrun.
+type Timer struct {
+	limit   time.Duration
+	start   time.Time
+	end     time.Time
+	running bool
+	expired bool
+	id      int // ticks of a previous clock are ignored
+	solved  int // speedrun only
+	failed  int // speedrun only
+}
+
+func NewTimer(options Options) Timer {
+	if !options.timed() {
+		return Timer{}
+	}
+	return Timer{limit: options.Limit}
+}
+
+// tickMsg is sent every TIMER_TICK while a clock runs.
+type tickMsg struct {
+	id   int
+	time time.Time
+}
+
+func (t Timer) enabled() bool {
+	return t.limit > 0
+}
+
+// begin starts the clock at now.
+func (t *Timer) begin(now time.Time) tea.Cmd {
+	t.id++
+	t.start = now
+	t.running = true
+	return t.tick()
+}
+
+func (t Timer) tick() tea.Cmd {
+	id := t.id
+	return tea.Tick(TIMER_TICK, func(now time.Time) tea.Msg {
+		return tickMsg{id: id, time: now}
+	})
+}
+
+// reset stops the clock for a new game of options, ticks still under way
+// are ignored.
+func (t *Timer) reset(options Options) {
+	id := t.id
+	*t = NewTimer(options)
+	t.id = id + 1
+}
+
+// due reports whether the time ran out at now.
+func (t Timer) due(now time.Time) bool {
+	return t.running && !now.Before(t.start.Add(t.limit))
+}
+
+// stop halts the clock at now, once the game is over.
+func (t *Timer) stop(now time.Time) {
+	t.running = false
+	t.end = now
+}
+
+// left returns the time left at now, or when the clock was stopped.
+func (t Timer) left(now time.Time) time.Duration {
+	if t.start.IsZero() {
+		return t.limit
+	}
+	if !t.running {
+		now = t.end
+	}
+	if left := t.start.Add(t.limit).Sub(now); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// grace reports whether the timeout happened too recently at now for keys
+// to start a new game.
+func (t Timer) grace(now time.Time) bool {
+	return t.expired && now.Sub(t.end) < TIMER_GRACE
+}
+
+// formatClock formats d as minutes and seconds, rounded up so the clock
+// only shows 00:00 once the time is up.
+func formatClock(d time.Duration) string {
+	seconds := int((d + time.Second - 1) / time.Second)
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// TimerView renders the clock above the board.
+func (m model) TimerView() string {
+	if !m.timer.enabled() {
+		return ""
+	}
+	left := m.timer.left(time.Now())
+	style := titleStyle.Copy().PaddingBottom(0)
+	if m.timer.running && left <= TIMER_WARNING {
+		style = style.Foreground(colorYellow)
+	}
+	text := formatClock(left)
+	if m.game.options.Mode == SPEEDRUN {
+		text += fmt.Sprintf("  solved %d", m.timer.solved)
+		if m.timer.failed > 0 {
+			text += fmt.Sprintf("  missed %d", m.timer.failed)
+		}
+	}
+	return lipgloss.NewStyle().MarginBottom(1).Render(style.Render(text))
+}
+
+// handleTick advances the clock and ends the game once the time is up.
+func (m *model) handleTick(msg tickMsg) tea.Cmd {
+	if msg.id != m.timer.id || !m.timer.running {
+		return nil
+	}
+	if m.timer.due(msg.time) {
+		m.timeout(msg.time)
+		return nil
+	}
+	return m.timer.tick()
+}
+
+// timeout ends the game, or the speedrun, when the time runs out.
+func (m *model) timeout(now time.Time) {
+	m.timer.stop(now)
+	m.timer.expired = true
+	m.game.timeout()
+	m.colours = nil
+	m.warning = m.game.message
+	if m.game.options.Mode == SPEEDRUN {
+		statistics, err := recordSpeedrun(m.game.options, m.timer.solved, m.timer.failed)
+		if err != nil {
+			m.warning = err.Error()
+			return
+		}
+		m.statistics = statistics
+		m.stats = true
+		return
+	}
+	statistics, err := recordGame(m.game)
+	if err != nil {
+		m.warning = err.Error()
+		return
+	}
+	m.statistics = statistics
+	m.stats = true
+	m.share = shareText(m.game, m.shareStyle)
+}
+
+// nextPuzzle moves a speedrun on to the next puzzle once one is finished,
+// the clock keeps running.
+func (m *model) nextPuzzle() {
+	if m.game.status == WIN {
+		m.timer.solved++
+	} else {
+		m.timer.failed++
+		missed := make([]string, 0, len(m.game.boards))
+		for _, board := range m.game.boards {
+			if board.status == LOSE {
+				missed = append(missed, strings.ToUpper(board.solution))
+			}
+		}
+		m.warning = fmt.Sprintf("missed %s", strings.Join(missed, " "))
+	}
+	game, err := startGame(m.options)
+	if err != nil {
+		m.warning = err.Error()
+		return
+	}
+	m.game = game
+	m.inputs = newInputs(game)
+	m.cursor = 0
+}
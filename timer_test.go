@@ -0,0 +1,138 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func NewTimedModel(t *testing.T, mode Mode) model {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	options := DefaultOptions()
+	options.Mode = mode
+	options.Limit = defaultLimit(mode)
+	m := NewTestModel(t, options)
+	m.game.boards[0].solution = "earth"
+	return m
+}
+
+func TestFormatClock(t *testing.T) {
+	tests := map[time.Duration]string{
+		3 * time.Minute:               "03:00",
+		90*time.Second + 1:            "01:31",
+		999 * time.Millisecond:        "00:01",
+		0:                             "00:00",
+		61 * time.Minute:              "61:00",
+		time.Minute - time.Nanosecond: "01:00",
+	}
+	for d, expected := range tests {
+		if clock := formatClock(d); clock != expected {
+			t.Errorf("Expected %s to be shown as %s but got %s", d, expected, clock)
+		}
+	}
+}
+
+func TestTimerStartsWithFirstLetter(t *testing.T) {
+	m := NewTimedModel(t, COUNTDOWN)
+	if m.timer.running || m.timer.left(time.Now()) != COUNTDOWN_LIMIT {
+		t.Fatalf("Expected the clock to wait for the first letter")
+	}
+	m, cmd := m.update(runes("e")[0])
+	if !m.timer.running || cmd == nil {
+		t.Errorf("Expected the clock to start ticking with the first letter")
+	}
+}
+
+func TestCountdownTimeout(t *testing.T) {
+	m := NewTimedModel(t, COUNTDOWN)
+	m = sendKeys(m, runes("adept")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	now := time.Now()
+	m.timer.start = now.Add(-COUNTDOWN_LIMIT + time.Second)
+	m, cmd := m.update(tickMsg{id: m.timer.id, time: now})
+	if m.game.status != ONGOING || cmd == nil {
+		t.Fatalf("Expected the clock to keep ticking before the time is up")
+	}
+	if m, cmd = m.update(tickMsg{id: m.timer.id - 1, time: now.Add(time.Minute)}); cmd != nil || m.game.status != ONGOING {
+		t.Errorf("Expected ticks of an old clock to be ignored")
+	}
+
+	m, cmd = m.update(tickMsg{id: m.timer.id, time: now.Add(time.Second)})
+	if m.game.status != LOSE || !m.timer.expired || cmd != nil {
+		t.Fatalf("Expected the game to be lost once the time is up")
+	}
+	if m.game.boards[0].status != LOSE || m.game.message != "time is up" {
+		t.Errorf("Expected the board to be lost but got '%s'", m.game.message)
+	}
+	if record := m.statistics.Modes["countdown 3m0s"]; record == nil || record.Played != 1 || record.Wins != 0 {
+		t.Errorf("Expected the timeout to be recorded as a countdown loss but got %+v", m.statistics.Modes)
+	}
+
+	game := m.game
+	m.timer.end = time.Now()
+	if m = sendKeys(m, runes("a")...); m.game != game {
+		t.Errorf("Expected keys right after the timeout to be ignored")
+	}
+	m.timer.end = time.Now().Add(-TIMER_GRACE)
+	if m = sendKeys(m, runes("a")...); m.game == game || m.timer.running || m.timer.expired {
+		t.Errorf("Expected a key to start a new game with a fresh clock")
+	}
+}
+
+func TestCountdownKeyAfterDeadline(t *testing.T) {
+	m := NewTimedModel(t, COUNTDOWN)
+	m = sendKeys(m, runes("earth")...)
+	// the deadline passed but the last tick did not arrive yet
+	m.timer.start = time.Now().Add(-COUNTDOWN_LIMIT)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.game.status != LOSE || m.game.attempt != 0 {
+		t.Errorf("Expected guesses after the deadline to be rejected")
+	}
+}
+
+func TestCountdownWinStopsClock(t *testing.T) {
+	m := NewTimedModel(t, COUNTDOWN)
+	m = sendKeys(m, runes("earth")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.game.status != WIN || m.timer.running || m.timer.expired {
+		t.Fatalf("Expected the clock to stop once the game is won")
+	}
+	if m, cmd := m.update(tickMsg{id: m.timer.id, time: time.Now().Add(time.Hour)}); cmd != nil || m.game.status != WIN {
+		t.Errorf("Expected ticks to stop once the game is won")
+	}
+	if record := m.statistics.Modes["countdown 3m0s"]; record == nil || record.Wins != 1 {
+		t.Errorf("Expected the win to be recorded but got %+v", m.statistics.Modes)
+	}
+}
+
+func TestSpeedrun(t *testing.T) {
+	m := NewTimedModel(t, SPEEDRUN)
+	m = sendKeys(m, runes("earth")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.timer.solved != 1 || m.game.status != ONGOING || m.game.attempt != 0 || !m.timer.running {
+		t.Fatalf("Expected the next puzzle once one is solved")
+	}
+
+	m.game.boards[0].solution = "earth"
+	m.game.options.Guesses = 1
+	m.game.boards[0].guesses = 1
+	m = sendKeys(m, runes("adept")...)
+	m = sendKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.timer.failed != 1 || m.warning != "missed EARTH" || m.game.attempt != 0 {
+		t.Fatalf("Expected the next puzzle once one is missed but got '%s'", m.warning)
+	}
+
+	m, _ = m.update(tickMsg{id: m.timer.id, time: m.timer.start.Add(SPEEDRUN_LIMIT)})
+	if !m.timer.expired || m.game.status != LOSE || !m.stats {
+		t.Fatalf("Expected the run to end once the time is up")
+	}
+	record := m.statistics.Speedruns["speedrun 5m0s"]
+	if record == nil || record.Runs != 1 || record.Best != 1 || record.Failed != 1 {
+		t.Errorf("Expected the run to be recorded but got %+v", record)
+	}
+	if m.statistics.Total.Played != 0 {
+		t.Errorf("Expected the puzzles of a run to be kept out of the totals")
+	}
+}
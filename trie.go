@@ -2,31 +2,33 @@ package main
 
 import (
 	"bytes"
-	_ "embed"
+	"embed"
 	"encoding/csv"
+	"fmt"
 	"math/rand"
+	"path"
+	"sync"
+	"unicode/utf8"
 )
 
-func alphabetIdx(char byte) int {
-	return int(char - 'a')
-}
-
 type Node struct {
-	value    byte
+	value    rune
+	index    int // of value in the alphabet
 	children []*Node
 	parent   *Node
 	isWord   bool
 }
 
-func NewNode(value byte) *Node {
+func NewNode(value rune, index int, size int) *Node {
 	return &Node{
 		value:    value,
-		children: make([]*Node, ALPHABET_LENGTH),
+		index:    index,
+		children: make([]*Node, size),
 	}
 }
 
 func (n *Node) hasSiblings() bool {
-	node_idx := alphabetIdx(n.value)
+	node_idx := n.index
 	siblings := append(n.parent.children[:node_idx], n.parent.children[node_idx+1:]...)
 	for _, sibling := range siblings {
 		if sibling != nil {
@@ -46,24 +48,29 @@ func (n *Node) getChildren() []*Node {
 	return result
 }
 
+// Trie holds words made of the letters of a language, every node has a child
+// slot per letter of the alphabet.
 type Trie struct {
 	head *Node
+	lang *Language
 }
 
-func NewTrie() Trie {
-	head := NewNode(' ')
+func NewTrie(lang *Language) Trie {
+	head := NewNode(' ', -1, len(lang.alphabet))
 	return Trie{
 		head: head,
+		lang: lang,
 	}
 }
 
+// insertWord adds word, which has to be made of letters of the alphabet.
 func (t *Trie) insertWord(word string) {
 	curr := t.head
 	for _, char := range word {
-		char_idx := alphabetIdx(byte(char))
+		char_idx := t.lang.idx(char)
 		var new_node *Node = nil
 		if next := curr.children[char_idx]; next == nil {
-			new_node = NewNode(byte(char))
+			new_node = NewNode(char, char_idx, len(t.lang.alphabet))
 			new_node.parent = curr
 			curr.children[char_idx] = new_node
 			curr = new_node
@@ -77,7 +84,10 @@ func (t *Trie) insertWord(word string) {
 func (t *Trie) findWord(word string) bool {
 	curr := t.head
 	for _, char := range word {
-		char_idx := alphabetIdx(byte(char))
+		char_idx := t.lang.idx(char)
+		if char_idx < 0 {
+			return false
+		}
 		if next := curr.children[char_idx]; next == nil {
 			return false
 		} else {
@@ -90,28 +100,31 @@ func (t *Trie) findWord(word string) bool {
 func (t *Trie) deleteWord(word string) {
 	curr := t.head
 	for _, char := range word {
-		char_idx := alphabetIdx(byte(char))
+		char_idx := t.lang.idx(char)
+		if char_idx < 0 {
+			return
+		}
 		if next := curr.children[char_idx]; next != nil {
 			curr = next
 		} else {
 			return
 		}
 	}
-	curr_idx := alphabetIdx(curr.value)
-	for i := 0; i < len(word); i++ {
+	curr_idx := curr.index
+	for i := 0; i < utf8.RuneCountInString(word); i++ {
 		if curr.hasSiblings() {
 			curr.parent.children[curr_idx] = nil
 			return
 		}
 		curr = curr.parent
-		curr_idx = alphabetIdx(curr.value)
+		curr_idx = curr.index
 	}
 }
 
 func (t *Trie) randomWord() string {
 	curr := t.head
 	word := ""
-	for i := 0; i < MAX_GUESSES; i++ {
+	for !curr.isWord {
 		children := curr.getChildren()
 		next := children[rand.Intn(len(children))]
 		word += string(next.value)
@@ -120,20 +133,141 @@ func (t *Trie) randomWord() string {
 	return word
 }
 
+// words returns every word in the trie in alphabetical order.
+func (t *Trie) words() []string {
+	words := make([]string, 0)
+	var walk func(curr *Node, prefix []rune)
+	walk = func(curr *Node, prefix []rune) {
+		if curr.isWord {
+			words = append(words, string(prefix))
+		}
+		for _, child := range curr.getChildren() {
+			walk(child, append(prefix, child.value))
+		}
+	}
+	walk(t.head, make([]rune, 0))
+	return words
+}
+
 //go:embed valid_solutions.csv
 var wordleSolutionsCSV []byte
 
 //go:embed valid_guesses.csv
 var wordleGuessesCSV []byte
 
-func (t *Trie) insertWordleData(data []byte) error {
+// Word lists for the other supported word lengths and languages, the lists
+// of a language live in a directory of its own. The guesses file only holds
+// the allowed words that are not also solutions and may be missing.
+//
+//go:embed words
+var wordLists embed.FS
+
+func dictionary(lang *Language, length int) ([]byte, []byte, error) {
+	if length == DEFAULT_WORD_LENGTH && lang.lists == "" {
+		return wordleSolutionsCSV, wordleGuessesCSV, nil
+	}
+	dir := path.Join("words", lang.lists)
+	solutions, err := wordLists.ReadFile(path.Join(dir, fmt.Sprintf("solutions_%d.csv", length)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error: No %s dictionary for words of length %d", lang.name, length)
+	}
+	guesses, err := wordLists.ReadFile(path.Join(dir, fmt.Sprintf("guesses_%d.csv", length)))
+	if err != nil {
+		guesses = nil
+	}
+	return solutions, guesses, nil
+}
+
+// dictionaryKey identifies the words of a language with a length.
+type dictionaryKey struct {
+	lang   string
+	length int
+}
+
+type tries struct {
+	solutions Trie
+	guesses   Trie
+}
+
+var (
+	triesMu    sync.Mutex
+	triesCache = make(map[dictionaryKey]tries)
+)
+
+// loadTries returns the solution and guess tries for words of the given
+// language and length. The tries are built once and shared between games, so
+// they must not be modified. Word lists loaded from files take the place of
+// the embedded ones, see useWordLists.
+func loadTries(lang *Language, length int) (Trie, Trie, error) {
+	triesMu.Lock()
+	defer triesMu.Unlock()
+	key := dictionaryKey{lang: lang.code, length: length}
+	if cached, ok := triesCache[key]; ok {
+		return cached.solutions, cached.guesses, nil
+	}
+
+	custom, ok := customLists[key]
+	solutions, guesses, err := dictionary(lang, length)
+	if err != nil {
+		// without an embedded dictionary both lists have to be given
+		switch {
+		case !ok:
+			return Trie{}, Trie{}, fmt.Errorf("%s, play your own with --solutions and --guess-list", err)
+		case custom.solutions == nil:
+			return Trie{}, Trie{}, fmt.Errorf("%s, --solutions is missing", err)
+		case custom.guesses == nil:
+			return Trie{}, Trie{}, fmt.Errorf("%s, --guess-list is missing", err)
+		}
+	}
+
+	solutionTrie := NewTrie(lang)
+	if custom.solutions != nil {
+		for _, word := range custom.solutions {
+			solutionTrie.insertWord(word)
+		}
+	} else if err := solutionTrie.insertWordleData(solutions, length); err != nil {
+		return Trie{}, Trie{}, err
+	}
+
+	guessTrie := NewTrie(lang)
+	if custom.guesses != nil {
+		for _, word := range custom.guesses {
+			guessTrie.insertWord(word)
+		}
+	} else {
+		if err := guessTrie.insertWordleData(guesses, length); err != nil {
+			return Trie{}, Trie{}, err
+		}
+		if err := guessTrie.insertWordleData(solutions, length); err != nil {
+			return Trie{}, Trie{}, err
+		}
+	}
+	// every solution can be guessed
+	for _, word := range solutionTrie.words() {
+		guessTrie.insertWord(word)
+	}
+
+	triesCache[key] = tries{solutions: solutionTrie, guesses: guessTrie}
+	return solutionTrie, guessTrie, nil
+}
+
+// insertWordleData inserts every word of the given length, skipping the
+// header row, entries of any other length and words with letters outside of
+// the alphabet.
+func (t *Trie) insertWordleData(data []byte, length int) error {
 	reader := csv.NewReader(bytes.NewReader(data))
 	words, err := reader.ReadAll()
 	if err != nil {
 		return err
 	}
 
-	for _, word := range words {
+	for i, word := range words {
+		if i == 0 && word[0] == "word" {
+			continue
+		}
+		if utf8.RuneCountInString(word[0]) != length || !t.lang.valid(word[0]) {
+			continue
+		}
 		t.insertWord(word[0])
 	}
 	return nil
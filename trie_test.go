@@ -5,14 +5,14 @@ import (
 )
 
 func NewTestTrie() *Trie {
-	trie := NewTrie()
+	trie := NewTrie(LANGUAGES[DEFAULT_LANGUAGE])
 	trie.insertWord("hello")
 	trie.insertWord("world")
 	return &trie
 }
 
 func TestInsertWord(t *testing.T) {
-	trie := NewTrie()
+	trie := NewTrie(LANGUAGES[DEFAULT_LANGUAGE])
 	trie.insertWord("hello")
 	if trie.head.children[7].children[4].children[11].children[11].children[14].isWord != true {
 		t.Errorf("Test failed: Word 'hello' not inserted correctly")
@@ -20,7 +20,7 @@ func TestInsertWord(t *testing.T) {
 }
 
 func TestInsertThreeWords(t *testing.T) {
-	trie := NewTrie()
+	trie := NewTrie(LANGUAGES[DEFAULT_LANGUAGE])
 	trie.insertWord("hello")
 	trie.insertWord("world")
 	trie.insertWord("ha")
@@ -35,6 +35,23 @@ func TestInsertThreeWords(t *testing.T) {
 	}
 }
 
+func TestInsertUnicodeWord(t *testing.T) {
+	de := LANGUAGES["de"]
+	trie := NewTrie(de)
+	trie.insertWord("größe")
+	trie.insertWord("grüße")
+	if !trie.findWord("größe") || trie.findWord("grosse") || trie.findWord("gröse") {
+		t.Errorf("Test failed: Word 'größe' not inserted correctly")
+	}
+	if words := trie.words(); len(words) != 2 || words[0] != "größe" {
+		t.Errorf("Test failed: Expected the words in alphabetical order but got %v", words)
+	}
+	trie.deleteWord("grüße")
+	if trie.findWord("grüße") || !trie.findWord("größe") {
+		t.Errorf("Test failed: Word 'grüße' not deleted correctly")
+	}
+}
+
 func TestFindWord(t *testing.T) {
 	trie := NewTestTrie()
 	if !trie.findWord("hello") {
@@ -56,8 +73,19 @@ func TestDeleteWord(t *testing.T) {
 }
 
 func TestInsertWordleData(t *testing.T) {
-	trie := NewTrie()
-	if err := trie.insertWordleData(wordleSolutionsCSV); err != nil {
+	trie := NewTrie(LANGUAGES[DEFAULT_LANGUAGE])
+	if err := trie.insertWordleData(wordleSolutionsCSV, DEFAULT_WORD_LENGTH); err != nil {
 		t.Errorf("Test failed: Something went wrong")
 	}
 }
+
+func TestRandomWord(t *testing.T) {
+	trie := NewTrie(LANGUAGES[DEFAULT_LANGUAGE])
+	trie.insertWord("cat")
+	trie.insertWord("cow")
+	for i := 0; i < 10; i++ {
+		if word := trie.randomWord(); !trie.findWord(word) {
+			t.Errorf("Test failed: Expected randomWord to return an inserted word but got '%s'", word)
+		}
+	}
+}
@@ -2,36 +2,49 @@ package main
 
 import (
 	"fmt"
-	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
-var (
-	ALPHABET        = []byte{'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h', 'i', 'j', 'k', 'l', 'm', 'n', 'o', 'p', 'q', 'r', 's', 't', 'u', 'v', 'w', 'x', 'y', 'z'}
-	ALPHABET_LENGTH = 26
-	GUESS_LENGTH    = 5
-	MAX_GUESSES     = 5 // zero indexed
+const (
+	MIN_WORD_LENGTH     = 4
+	MAX_WORD_LENGTH     = 8
+	DEFAULT_WORD_LENGTH = 5
+	MIN_GUESSES         = 1
+	MAX_GUESSES         = 20
+	DEFAULT_GUESSES     = 6
 )
 
-func inAlphabet(char byte) bool {
-	index := sort.Search(len(ALPHABET), func(i int) bool {
-		return ALPHABET[i] >= char
-	})
-	return index < len(ALPHABET) && ALPHABET[index] == char
-}
-
 type Wordle struct {
 	board     []Guess
 	attempt   int
+	length    int
+	guesses   int
 	solution  string
 	status    GameStatus
+	lang      *Language
 	trie      Trie
 	guessTrie Trie
+	adversary *CandidateSet        // absurdle only
 	assign    map[int]int          // green
-	veto      map[int]map[int]bool // yellow
-	include   map[int]bool         // yellow & grey
+	veto      map[int]map[int]bool // yellow & grey
+	minCount  map[int]int          // yellow & green
+	maxCount  map[int]int          // grey
+	hard      bool
 	message   string
 }
 
+// HardModeError is returned by guess when hard mode is enabled and the guess
+// does not make use of the hints revealed so far.
+type HardModeError struct {
+	message string
+}
+
+func (e *HardModeError) Error() string {
+	return fmt.Sprintf("Error: %s", e.message)
+}
+
 type GameStatus int
 
 const (
@@ -49,116 +62,413 @@ const (
 	GREEN
 )
 
+var feedbackNames = map[Feedback]string{
+	TBD:    "tbd",
+	GREY:   "grey",
+	YELLOW: "yellow",
+	GREEN:  "green",
+}
+
+func (f Feedback) String() string {
+	return feedbackNames[f]
+}
+
+func (f Feedback) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+func (f *Feedback) UnmarshalText(text []byte) error {
+	for feedback, name := range feedbackNames {
+		if name == string(text) {
+			*f = feedback
+			return nil
+		}
+	}
+	return fmt.Errorf("Error: Unknown feedback '%s'", text)
+}
+
 type Guess []*GuessChar
 
+// feedbackChars spell out feedback as letters: green, yellow and black for
+// grey tiles.
+var feedbackChars = map[Feedback]byte{
+	TBD:    '.',
+	GREY:   'b',
+	YELLOW: 'y',
+	GREEN:  'g',
+}
+
+// parsePattern is the inverse of Guess.pattern, it reads the feedback of a
+// guess from a string like "gybgg".
+func parsePattern(pattern string, length int) ([]Feedback, error) {
+	if len(pattern) != length {
+		return nil, fmt.Errorf("Error: Feedback has to be %d characters long", length)
+	}
+	feedback := make([]Feedback, length)
+	for i := 0; i < len(pattern); i++ {
+		feedback[i] = TBD
+		for value, char := range feedbackChars {
+			if value != TBD && char == pattern[i] {
+				feedback[i] = value
+			}
+		}
+		if feedback[i] == TBD {
+			return nil, fmt.Errorf("Error: Invalid feedback '%c', expected one of 'g', 'y' or 'b'", pattern[i])
+		}
+	}
+	return feedback, nil
+}
+
+// pattern returns the feedback of the guess as a string, e.g. "gybgg".
+func (g Guess) pattern() string {
+	pattern := make([]byte, len(g))
+	for i, char := range g {
+		pattern[i] = feedbackChars[char.feedback]
+	}
+	return string(pattern)
+}
+
+func (g Guess) word() string {
+	word := ""
+	for _, char := range g {
+		word += string(char.value)
+	}
+	return word
+}
+
 type GuessChar struct {
-	value    byte
+	value    rune
 	feedback Feedback
 }
 
-func NewGuess(word string) (Guess, error) {
-	guess := make([]*GuessChar, GUESS_LENGTH)
-	if len(word) != GUESS_LENGTH {
-		return guess, fmt.Errorf("Error: Guess has to be %d characters long", GUESS_LENGTH)
+func NewGuess(word string, lang *Language, length int) (Guess, error) {
+	guess := make([]*GuessChar, length)
+	if utf8.RuneCountInString(word) != length {
+		return guess, fmt.Errorf("Error: Guess has to be %d characters long", length)
 	}
-	for i, char := range word {
-		if !inAlphabet(byte(char)) {
+	for i, char := range []rune(word) {
+		if !lang.contains(char) {
 			return guess, fmt.Errorf("Error: Invalid character")
 		}
-		guess[i] = &GuessChar{value: byte(char), feedback: TBD}
+		guess[i] = &GuessChar{value: char, feedback: TBD}
 	}
 	return guess, nil
 }
 
-func NewWordle() *Wordle {
-	board := make([]Guess, MAX_GUESSES+1)
+type Mode int
+
+const (
+	CLASSIC Mode = iota
+	ABSURDLE
+	DAILY
+	CHALLENGE
+	ASSIST
+	COUNTDOWN
+	SPEEDRUN
+)
+
+var modeNames = map[Mode]string{
+	CLASSIC:   "classic",
+	ABSURDLE:  "absurdle",
+	DAILY:     "daily",
+	CHALLENGE: "challenge",
+	ASSIST:    "assist",
+	COUNTDOWN: "countdown",
+	SPEEDRUN:  "speedrun",
+}
 
-	trie := NewTrie()
-	if err := trie.insertWordleData(wordleSolutionsCSV); err != nil {
-		return nil
+func (m Mode) String() string {
+	return modeNames[m]
+}
+
+func parseMode(name string) (Mode, error) {
+	for mode, mode_name := range modeNames {
+		if mode_name == name {
+			return mode, nil
+		}
 	}
+	return CLASSIC, fmt.Errorf("Error: Unknown mode '%s'", name)
+}
 
-	guessTrie := NewTrie()
-	if err := guessTrie.insertWordleData(wordleGuessesCSV); err != nil {
-		return nil
+func (m Mode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Mode) UnmarshalText(text []byte) error {
+	mode, err := parseMode(string(text))
+	*m = mode
+	return err
+}
+
+type Options struct {
+	Length   int           `json:"length"`
+	Guesses  int           `json:"guesses"`
+	Boards   int           `json:"boards"`
+	Mode     Mode          `json:"mode"`
+	Puzzle   int           `json:"puzzle,omitempty"`   // daily only
+	Solution string        `json:"solution,omitempty"` // challenge and countdown only
+	Hard     bool          `json:"hard"`
+	Lang     string        `json:"lang,omitempty"`  // language code, English if empty
+	Limit    time.Duration `json:"limit,omitempty"` // countdown and speedrun only
+}
+
+// timed reports whether the game is played against the clock.
+func (o Options) timed() bool {
+	return o.Mode == COUNTDOWN || o.Mode == SPEEDRUN
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Length:  DEFAULT_WORD_LENGTH,
+		Guesses: DEFAULT_GUESSES,
+		Boards:  1,
+		Mode:    CLASSIC,
+		Hard:    false,
 	}
-    if err := guessTrie.insertWordleData(wordleSolutionsCSV); err != nil {
-        return nil
-    }
+}
 
-	veto := make(map[int]map[int]bool, GUESS_LENGTH)
-	for i := 0; i < GUESS_LENGTH; i++ {
-		veto[i] = make(map[int]bool, ALPHABET_LENGTH)
+func NewWordle(options Options) (*Wordle, error) {
+	if options.Length < MIN_WORD_LENGTH || options.Length > MAX_WORD_LENGTH {
+		return nil, fmt.Errorf("Error: Word length has to be between %d and %d", MIN_WORD_LENGTH, MAX_WORD_LENGTH)
+	}
+	if options.Guesses < MIN_GUESSES || options.Guesses > MAX_GUESSES {
+		return nil, fmt.Errorf("Error: Number of guesses has to be between %d and %d", MIN_GUESSES, MAX_GUESSES)
+	}
+	board := make([]Guess, options.Guesses)
+
+	lang, err := language(options.Lang)
+	if err != nil {
+		return nil, err
+	}
+	trie, guessTrie, err := loadTries(lang, options.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	veto := make(map[int]map[int]bool, options.Length)
+	for i := 0; i < options.Length; i++ {
+		veto[i] = make(map[int]bool, len(lang.alphabet))
 	}
 
 	wordle := &Wordle{
 		board:     board,
 		attempt:   0,
+		length:    options.Length,
+		guesses:   options.Guesses,
+		lang:      lang,
 		trie:      trie,
 		guessTrie: guessTrie,
-		assign:    make(map[int]int),  // idx -> char_idx
-		include:   make(map[int]bool), // char_idx -> bool
-		veto:      veto,               // idx -> char_idx -> bool
+		assign:    make(map[int]int), // idx -> char_idx
+		minCount:  make(map[int]int), // char_idx -> count
+		maxCount:  make(map[int]int), // char_idx -> count
+		veto:      veto,              // idx -> char_idx -> bool
+		hard:      options.Hard,
+	}
+	switch options.Mode {
+	case ABSURDLE:
+		// the solution is only settled once a single candidate is left
+		wordle.adversary = NewCandidateSet(trie.words())
+	case DAILY:
+		if options.Puzzle < 1 {
+			return nil, fmt.Errorf("Error: Invalid daily puzzle number %d", options.Puzzle)
+		}
+		wordle.solution = dailySolution(trie.words(), options.Puzzle)
+	case CHALLENGE:
+		if !guessTrie.findWord(options.Solution) || utf8.RuneCountInString(options.Solution) != options.Length {
+			return nil, fmt.Errorf("Error: Invalid challenge solution")
+		}
+		wordle.solution = options.Solution
+	case COUNTDOWN:
+		// the word of a challenge can be played against the clock
+		if options.Solution == "" {
+			wordle.solution = trie.randomWord()
+		} else if !guessTrie.findWord(options.Solution) || utf8.RuneCountInString(options.Solution) != options.Length {
+			return nil, fmt.Errorf("Error: Invalid challenge solution")
+		} else {
+			wordle.solution = options.Solution
+		}
+	case ASSIST:
+		// the puzzle is played elsewhere, the player enters the feedback
+	default:
+		wordle.solution = trie.randomWord()
 	}
-	wordle.solution = trie.randomWord()
 
-	return wordle
+	return wordle, nil
 }
 
-func (w *Wordle) guess(word string) error {
-	new_guess, err := NewGuess(word)
+// check returns the guess for word if it can be submitted in the current
+// state of the game.
+func (w *Wordle) check(word string) (Guess, error) {
+	if w.status != ONGOING {
+		w.message = "the game is over"
+		return nil, fmt.Errorf("Error: Game is over")
+	}
+	new_guess, err := NewGuess(word, w.lang, w.length)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if valid := w.guessTrie.findWord(word); !valid {
 		w.message = fmt.Sprintf("'%s' is not a valid word", word)
-		return fmt.Errorf("Error: Invalid word")
+		return nil, fmt.Errorf("Error: Invalid word")
+	}
+	if w.hard {
+		if err := w.validateHard(new_guess); err != nil {
+			w.message = err.message
+			return nil, err
+		}
+	}
+	return new_guess, nil
+}
+
+func (w *Wordle) guess(word string) error {
+	if w.solution == "" && w.adversary == nil {
+		w.message = "enter the colours of the tiles"
+		return fmt.Errorf("Error: Feedback has to be entered")
+	}
+	new_guess, err := w.check(word)
+	if err != nil {
+		return err
+	}
+	if w.adversary != nil {
+		w.solution = w.adversary.narrow(word)
 	}
+	w.apply(new_guess, score(word, w.solution))
+	return nil
+}
+
+// enter submits word with the feedback entered by the player instead of
+// scoring it against the solution, for puzzles played elsewhere.
+func (w *Wordle) enter(word string, feedback []Feedback) error {
+	new_guess, err := w.check(word)
+	if err != nil {
+		return err
+	}
+	if len(feedback) != w.length {
+		w.message = fmt.Sprintf("colour all %d tiles", w.length)
+		return fmt.Errorf("Error: Feedback has to be given for %d characters", w.length)
+	}
+	for _, value := range feedback {
+		if value == TBD {
+			w.message = fmt.Sprintf("colour all %d tiles", w.length)
+			return fmt.Errorf("Error: Feedback is incomplete")
+		}
+	}
+	w.apply(new_guess, feedback)
+	return nil
+}
+
+// apply records the guess with its feedback on the board and updates the
+// constraints and status.
+func (w *Wordle) apply(new_guess Guess, feedback []Feedback) {
 	w.board[w.attempt] = new_guess
 	num_correct := 0
-	for i, char := range w.board[w.attempt] {
-		char_idx := alphabetIdx(char.value)
-		if char.value == w.solution[i] {
-			char.feedback = GREEN
-			w.assign[i] = char_idx
+	for i := range feedback {
+		new_guess[i].feedback = feedback[i]
+		if feedback[i] == GREEN {
 			num_correct++
-		} else if w.solutionContains(char.value) {
-			char.feedback = YELLOW
-			w.include[char_idx] = true
-			w.veto[i][char_idx] = true
-		} else {
-			char.feedback = GREY
-			w.include[char_idx] = false
 		}
 	}
+	w.constrain(new_guess)
 
-	if num_correct == GUESS_LENGTH {
+	w.attempt++
+	if num_correct == w.length {
 		w.status = WIN
-	} else if w.attempt == MAX_GUESSES {
+	} else if w.attempt == w.guesses {
 		w.status = LOSE
 	} else {
 		w.status = ONGOING
 	}
-	w.attempt++
-	return nil
+}
+
+// score compares word against solution following the rules of the original
+// game: exact matches are marked first, then misplaced letters consume the
+// remaining letters of the solution from left to right. Surplus copies of a
+// letter are marked grey.
+func score(word string, solution string) []Feedback {
+	guess, target := []rune(word), []rune(solution)
+	feedback := make([]Feedback, len(guess))
+	remaining := make(map[rune]int, len(target))
+	for i := range guess {
+		if guess[i] == target[i] {
+			feedback[i] = GREEN
+		} else {
+			remaining[target[i]]++
+		}
+	}
+	for i := range guess {
+		if feedback[i] == GREEN {
+			continue
+		}
+		if remaining[guess[i]] > 0 {
+			feedback[i] = YELLOW
+			remaining[guess[i]]--
+		} else {
+			feedback[i] = GREY
+		}
+	}
+	return feedback
+}
+
+// encodeFeedback packs feedback into a single base 3 number with the first
+// letter as the least significant digit.
+func encodeFeedback(feedback []Feedback) int {
+	pattern := 0
+	for i := len(feedback) - 1; i >= 0; i-- {
+		pattern = pattern*3 + int(feedback[i]-GREY)
+	}
+	return pattern
+}
+
+// constrain updates the knowledge about the solution with the feedback of
+// guess. A letter that is marked grey while other copies are marked green or
+// yellow pins down its exact number of occurrences.
+func (w *Wordle) constrain(guess Guess) {
+	found := make(map[int]int)
+	absent := make(map[int]bool)
+	for i, char := range guess {
+		char_idx := w.lang.idx(char.value)
+		switch char.feedback {
+		case GREEN:
+			w.assign[i] = char_idx
+			found[char_idx]++
+		case YELLOW:
+			w.veto[i][char_idx] = true
+			found[char_idx]++
+		case GREY:
+			w.veto[i][char_idx] = true
+			absent[char_idx] = true
+		}
+	}
+	for char_idx, count := range found {
+		if count > w.minCount[char_idx] {
+			w.minCount[char_idx] = count
+		}
+	}
+	for char_idx := range absent {
+		w.maxCount[char_idx] = found[char_idx]
+	}
 }
 
 func (w *Wordle) validate(guess Guess) bool {
+	counts := make(map[int]int)
 	for i, char := range guess {
-		char_idx := alphabetIdx(char.value)
+		char_idx := w.lang.idx(char.value)
+		counts[char_idx]++
 
 		if assigned, ok := w.assign[i]; ok {
 			if assigned != char_idx {
-				w.message = fmt.Sprintf("'%s' is at index %d of the solution", string(ALPHABET[assigned]), i)
+				w.message = fmt.Sprintf("'%s' is at index %d of the solution", string(w.lang.alphabet[assigned]), i)
 				return false
 			}
 		}
 
-		if included, ok := w.include[char_idx]; ok {
-			if !included {
+		if max, ok := w.maxCount[char_idx]; ok && counts[char_idx] > max {
+			if max == 0 {
 				w.message = fmt.Sprintf("'%s' is not part of the solution", string(char.value))
-				return false
+			} else {
+				w.message = fmt.Sprintf("'%s' appears only %d time(s) in the solution", string(char.value), max)
 			}
+			return false
 		}
 
 		if veto, ok := w.veto[i]; ok {
@@ -178,37 +488,90 @@ func (w *Wordle) validateFull(guess Guess) bool {
 		return false
 	}
 
-	found_included := make(map[int]bool)
+	counts := make(map[int]int)
 	for _, char := range guess {
-		char_idx := alphabetIdx(char.value)
-		if included, ok := w.include[char_idx]; ok && included {
-			found_included[char_idx] = true
-		}
+		counts[w.lang.idx(char.value)]++
 	}
 
-	for char_idx, include := range w.include {
-		if include && !found_included[char_idx] {
-			w.message = fmt.Sprintf("'%s' is part of the solution", string(ALPHABET[char_idx]))
-			return false
+	for char_idx := range w.lang.alphabet {
+		min := w.minCount[char_idx]
+		if counts[char_idx] >= min {
+			continue
+		}
+		if min == 1 {
+			w.message = fmt.Sprintf("'%s' is part of the solution", string(w.lang.alphabet[char_idx]))
+		} else {
+			w.message = fmt.Sprintf("'%s' appears at least %d times in the solution", string(w.lang.alphabet[char_idx]), min)
 		}
+		return false
 	}
 
 	w.message = ""
 	return true
 }
 
-func (w *Wordle) suggestNextGuess() string {
-	guess := w.findGuessBacktrack()
-	word := ""
-	for _, char := range guess {
-		word += string(char.value)
+// setHardMode toggles hard mode. It can only be changed before the first
+// guess has been made.
+func (w *Wordle) setHardMode(hard bool) error {
+	if w.attempt > 0 {
+		w.message = "hard mode can only be changed before the first guess"
+		return fmt.Errorf("Error: Game already started")
 	}
-	return word
+	w.hard = hard
+	return nil
+}
+
+// validateHard checks guess against the official hard mode rules: green
+// letters have to stay in place and yellow letters have to be reused. Unlike
+// validateFull, grey letters and yellow letters in their old position are
+// allowed.
+func (w *Wordle) validateHard(guess Guess) *HardModeError {
+	counts := make(map[int]int)
+	for i, char := range guess {
+		char_idx := w.lang.idx(char.value)
+		counts[char_idx]++
+		if assigned, ok := w.assign[i]; ok && assigned != char_idx {
+			return &HardModeError{
+				message: fmt.Sprintf("%s letter must be %s", ordinal(i+1), strings.ToUpper(string(w.lang.alphabet[assigned]))),
+			}
+		}
+	}
+
+	for char_idx := range w.lang.alphabet {
+		min := w.minCount[char_idx]
+		if counts[char_idx] >= min {
+			continue
+		}
+		letter := strings.ToUpper(string(w.lang.alphabet[char_idx]))
+		if min == 1 {
+			return &HardModeError{message: fmt.Sprintf("Guess must contain %s", letter)}
+		}
+		return &HardModeError{message: fmt.Sprintf("Guess must contain %s %d times", letter, min)}
+	}
+	return nil
+}
+
+func ordinal(n int) string {
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+		return fmt.Sprintf("%dth", n)
+	case n%10 == 1:
+		return fmt.Sprintf("%dst", n)
+	case n%10 == 2:
+		return fmt.Sprintf("%dnd", n)
+	case n%10 == 3:
+		return fmt.Sprintf("%drd", n)
+	}
+	return fmt.Sprintf("%dth", n)
+}
+
+func (w *Wordle) suggestNextGuess() string {
+	return w.findGuessBacktrack().word()
 }
 
 func (w *Wordle) findGuessBacktrack() Guess {
 	if w.attempt == 0 {
-		random_guess, err := NewGuess(w.trie.randomWord())
+		random_guess, err := NewGuess(w.trie.randomWord(), w.lang, w.length)
 		if err != nil {
 			return nil
 		}
@@ -219,8 +582,60 @@ func (w *Wordle) findGuessBacktrack() Guess {
 	return w.backtrack(guess, w.trie.head)
 }
 
+// patterns returns the feedback of every guess on the board.
+func (w *Wordle) patterns() []string {
+	patterns := make([]string, 0, w.attempt)
+	for _, guess := range w.board[:w.attempt] {
+		patterns = append(patterns, guess.pattern())
+	}
+	return patterns
+}
+
+// letterFeedback summarizes what is known about a letter for the keyboard.
+func (w *Wordle) letterFeedback(char_idx int) Feedback {
+	for _, assigned := range w.assign {
+		if assigned == char_idx {
+			return GREEN
+		}
+	}
+	if w.minCount[char_idx] > 0 {
+		return YELLOW
+	}
+	if max, ok := w.maxCount[char_idx]; ok && max == 0 {
+		return GREY
+	}
+	return TBD
+}
+
+// consistentWords returns every solution that is still possible given the
+// feedback on the board.
+func (w *Wordle) consistentWords() []string {
+	words := make([]string, 0)
+	w.collect(make(Guess, 0, w.length), w.trie.head, &words)
+	return words
+}
+
+func (w *Wordle) collect(guess Guess, curr *Node, words *[]string) {
+	if len(guess) == w.length {
+		if curr.isWord && w.validateFull(guess) {
+			*words = append(*words, guess.word())
+		}
+		return
+	}
+
+	for _, child := range curr.children {
+		if child == nil {
+			continue
+		}
+		temp_guess := append(guess, &GuessChar{value: child.value, feedback: TBD})
+		if w.validate(temp_guess) {
+			w.collect(temp_guess, child, words)
+		}
+	}
+}
+
 func (w *Wordle) backtrack(guess Guess, curr *Node) Guess {
-	if len(guess) == GUESS_LENGTH && curr.isWord {
+	if len(guess) == w.length && curr.isWord {
 		return guess
 	}
 
@@ -239,12 +654,3 @@ func (w *Wordle) backtrack(guess Guess, curr *Node) Guess {
 
 	return nil
 }
-
-func (w *Wordle) solutionContains(char byte) bool {
-	for _, c := range w.solution {
-		if byte(c) == char {
-			return true
-		}
-	}
-	return false
-}
@@ -5,7 +5,10 @@ import (
 )
 
 func NewTestWordle() *Wordle {
-	wordle := NewWordle()
+	wordle, err := NewWordle(DefaultOptions())
+	if err != nil {
+		panic(err)
+	}
 	wordle.solution = "earth"
 	return wordle
 }
@@ -19,7 +22,7 @@ func TestGuess(t *testing.T) {
 	}
 
 	for i, char := range guess {
-		if byte(char) != wordle.board[0][i].value {
+		if char != wordle.board[0][i].value {
 			t.Errorf("Expected 'adept' to be in row 0 of the wordle board.")
 		}
 	}
@@ -51,7 +54,7 @@ func TestValidate(t *testing.T) {
 	guess := make([]*GuessChar, 5)
 	word := "taste"
 	for i := range word {
-		guess[i] = &GuessChar{value: byte(word[i]), feedback: TBD}
+		guess[i] = &GuessChar{value: rune(word[i]), feedback: TBD}
 	}
 
 	// valid
@@ -62,7 +65,7 @@ func TestValidate(t *testing.T) {
 	// veto
 	word = "adult"
 	for i := range word {
-		guess[i] = &GuessChar{value: byte(word[i]), feedback: TBD}
+		guess[i] = &GuessChar{value: rune(word[i]), feedback: TBD}
 	}
 	if valid := wordle.validate(guess); valid {
 		t.Errorf("Expected 'adult' to be an invalid guess following 'adept'")
@@ -71,13 +74,64 @@ func TestValidate(t *testing.T) {
 	// include/exclude
 	word = "drown"
 	for i := range word {
-		guess[i] = &GuessChar{value: byte(word[i]), feedback: TBD}
+		guess[i] = &GuessChar{value: rune(word[i]), feedback: TBD}
 	}
 	if valid := wordle.validate(guess); valid {
 		t.Errorf("Expected 'drown' to be an invalid guess following 'adept'")
 	}
 }
 
+func TestValidateHard(t *testing.T) {
+	tests := []struct {
+		name    string
+		guesses []string
+		word    string
+		valid   bool
+	}{
+		{"no hints yet", nil, "adept", true},
+		{"yellows reused", []string{"adept"}, "taste", true},
+		{"yellow in same position", []string{"adept"}, "abate", true},
+		{"revealed letter missing", []string{"adept"}, "ample", false},
+		{"yellow letters moved", []string{"adept"}, "treat", true},
+		{"grey letters allowed", []string{"adept"}, "dealt", true},
+		{"yellow missing", []string{"adept"}, "toast", false},
+		{"green kept", []string{"baste"}, "mates", false},
+		{"green kept with yellows", []string{"baste"}, "earth", true},
+		{"green moved", []string{"bathe"}, "alert", false},
+		{"greys ignored", []string{"eerie"}, "earns", true},
+	}
+
+	for _, test := range tests {
+		wordle := NewTestWordle()
+		wordle.setHardMode(true)
+		for _, guess := range test.guesses {
+			if err := wordle.guess(guess); err != nil {
+				t.Fatalf("%s: Expected guess '%s' to be successful but got %s", test.name, guess, err)
+			}
+		}
+
+		err := wordle.guess(test.word)
+		if test.valid && err != nil {
+			t.Errorf("%s: Expected '%s' to be accepted in hard mode but got %s", test.name, test.word, err)
+		}
+		if !test.valid {
+			if _, ok := err.(*HardModeError); !ok {
+				t.Errorf("%s: Expected '%s' to be rejected in hard mode but got %v", test.name, test.word, err)
+			}
+		}
+	}
+}
+
+func TestSetHardModeAfterGuess(t *testing.T) {
+	wordle := NewTestWordle()
+	if err := wordle.guess("adept"); err != nil {
+		t.Errorf("Expected guess to be successful but got %s", err)
+	}
+	if err := wordle.setHardMode(true); err == nil {
+		t.Errorf("Expected hard mode to be locked after the first guess")
+	}
+}
+
 func TestFindGuessBacktrack(t *testing.T) {
 	wordle := NewTestWordle()
 	err := wordle.guess("adept")
@@ -117,3 +171,175 @@ func TestFindGuessBacktrack(t *testing.T) {
 		t.Errorf("Expected status to be 'WIN'")
 	}
 }
+
+func TestScoreDuplicateLetters(t *testing.T) {
+	tests := []struct {
+		word     string
+		solution string
+		expected []Feedback
+	}{
+		{"geese", "earth", []Feedback{GREY, YELLOW, GREY, GREY, GREY}},
+		{"eerie", "earth", []Feedback{GREEN, GREY, GREEN, GREY, GREY}},
+		{"speed", "abide", []Feedback{GREY, GREY, YELLOW, GREY, YELLOW}},
+		{"error", "rarer", []Feedback{YELLOW, YELLOW, GREEN, GREY, GREEN}},
+		{"llama", "allow", []Feedback{YELLOW, GREEN, YELLOW, GREY, GREY}},
+	}
+
+	for _, test := range tests {
+		feedback := score(test.word, test.solution)
+		for i := range test.expected {
+			if feedback[i] != test.expected[i] {
+				t.Errorf("Expected feedback %v for '%s' against '%s' but got %v", test.expected, test.word, test.solution, feedback)
+				break
+			}
+		}
+	}
+}
+
+func TestLetterCountConstraints(t *testing.T) {
+	wordle := NewTestWordle()
+	if err := wordle.guess("geese"); err != nil {
+		t.Errorf("Expected guess to be successful but got %s", err)
+	}
+
+	e := wordle.lang.idx('e')
+	if wordle.minCount[e] != 1 || wordle.maxCount[e] != 1 {
+		t.Errorf("Expected 'e' to appear exactly once but got min %d max %d", wordle.minCount[e], wordle.maxCount[e])
+	}
+
+	tests := []struct {
+		word  string
+		valid bool
+	}{
+		{"earth", true},
+		{"there", false}, // two e's
+		{"alarm", false}, // no e
+		{"elder", false}, // e vetoed at index 1
+	}
+
+	for _, test := range tests {
+		guess, err := NewGuess(test.word, wordle.lang, wordle.length)
+		if err != nil {
+			t.Fatalf("Expected '%s' to be a valid guess but got %s", test.word, err)
+		}
+		if valid := wordle.validateFull(guess); valid != test.valid {
+			t.Errorf("Expected validateFull('%s') to be %t following 'geese'", test.word, test.valid)
+		}
+	}
+}
+
+func TestWordLength(t *testing.T) {
+	for length := MIN_WORD_LENGTH; length <= MAX_WORD_LENGTH; length++ {
+		wordle, err := NewWordle(Options{Length: length, Guesses: DEFAULT_GUESSES})
+		if err != nil {
+			t.Fatalf("Expected a game with %d letters but got %s", length, err)
+		}
+		if len(wordle.solution) != length {
+			t.Errorf("Expected a solution with %d letters but got '%s'", length, wordle.solution)
+		}
+		if err := wordle.guess(wordle.solution); err != nil {
+			t.Errorf("Expected guess to be successful but got %s", err)
+		}
+		if wordle.status != WIN {
+			t.Errorf("Expected status to be 'WIN' for %d letters", length)
+		}
+	}
+
+	// words that are allowed but never the solution
+	for _, word := range []string{"jumped", "thunder", "overcast"} {
+		wordle, _ := NewWordle(Options{Length: len(word), Guesses: DEFAULT_GUESSES})
+		if wordle.trie.findWord(word) || !wordle.guessTrie.findWord(word) {
+			t.Errorf("Expected '%s' to be a guess but not a solution", word)
+		}
+	}
+
+	if _, err := NewWordle(Options{Length: MAX_WORD_LENGTH + 1, Guesses: DEFAULT_GUESSES}); err == nil {
+		t.Errorf("Expected an error for words with %d letters", MAX_WORD_LENGTH+1)
+	}
+}
+
+func TestGuessBudget(t *testing.T) {
+	for _, guesses := range []int{1, 4, 10} {
+		options := DefaultOptions()
+		options.Guesses = guesses
+		wordle, err := NewWordle(options)
+		if err != nil {
+			t.Fatalf("Expected a game with %d guesses but got %s", guesses, err)
+		}
+		wordle.solution = "earth"
+
+		for i := 0; i < guesses; i++ {
+			if wordle.status != ONGOING {
+				t.Fatalf("Expected status to be 'ONGOING' after %d of %d guesses", i, guesses)
+			}
+			if err := wordle.guess("adept"); err != nil {
+				t.Fatalf("Expected guess to be successful but got %s", err)
+			}
+		}
+
+		if wordle.status != LOSE {
+			t.Errorf("Expected status to be 'LOSE' after %d guesses", guesses)
+		}
+		if err := wordle.guess("earth"); err == nil {
+			t.Errorf("Expected guess after the game ended to fail")
+		}
+	}
+
+	options := DefaultOptions()
+	options.Guesses = 0
+	if _, err := NewWordle(options); err == nil {
+		t.Errorf("Expected an error for a game without guesses")
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	feedback, err := parsePattern("gybgg", 5)
+	if err != nil {
+		t.Fatalf("Expected pattern to be parsed but got %s", err)
+	}
+	expected := []Feedback{GREEN, YELLOW, GREY, GREEN, GREEN}
+	for i := range expected {
+		if feedback[i] != expected[i] {
+			t.Errorf("Expected feedback %v but got %v", expected, feedback)
+			break
+		}
+	}
+	for _, pattern := range []string{"gybg", "gyb.g", "gyxgg"} {
+		if _, err := parsePattern(pattern, 5); err == nil {
+			t.Errorf("Expected pattern '%s' to be rejected", pattern)
+		}
+	}
+}
+
+func TestEnterFeedback(t *testing.T) {
+	options := DefaultOptions()
+	options.Mode = ASSIST
+	wordle, err := NewWordle(options)
+	if err != nil {
+		t.Fatalf("Expected wordle to be created but got %s", err)
+	}
+	if err := wordle.guess("adept"); err == nil {
+		t.Errorf("Expected guess without feedback to be rejected")
+	}
+	if err := wordle.enter("adept", []Feedback{YELLOW, GREY, YELLOW, GREY, YELLOW}); err != nil {
+		t.Fatalf("Expected feedback to be entered but got %s", err)
+	}
+	if pattern := wordle.board[0].pattern(); pattern != "ybyby" {
+		t.Errorf("Expected the entered pattern 'ybyby' but got '%s'", pattern)
+	}
+	// same feedback as if the solution was 'earth'
+	for _, word := range wordle.consistentWords() {
+		if feedbackPattern("adept", word) != encodeFeedback([]Feedback{YELLOW, GREY, YELLOW, GREY, YELLOW}) {
+			t.Errorf("Expected '%s' to be inconsistent with the entered feedback", word)
+		}
+	}
+	if err := wordle.enter("earth", []Feedback{GREEN, GREEN, TBD, GREEN, GREEN}); err == nil {
+		t.Errorf("Expected incomplete feedback to be rejected")
+	}
+	if err := wordle.enter("earth", []Feedback{GREEN, GREEN, GREEN, GREEN, GREEN}); err != nil {
+		t.Fatalf("Expected feedback to be entered but got %s", err)
+	}
+	if wordle.status != WIN {
+		t.Errorf("Expected status to be 'WIN' once every tile is green")
+	}
+}
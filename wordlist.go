@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// WORD_LIST_ERRORS is the number of bad entries reported for a word list.
+const WORD_LIST_ERRORS = 10
+
+// WordLists replace the embedded word lists for a language and word length.
+// A nil list falls back to the embedded one.
+type WordLists struct {
+	solutions []string
+	guesses   []string
+}
+
+// customLists holds the word lists loaded from files, guarded by triesMu.
+var customLists = make(map[dictionaryKey]WordLists)
+
+// useWordLists replaces the word lists for words of lang and length. Games
+// created afterwards use them, passing nil lists restores the embedded ones.
+func useWordLists(lang *Language, length int, solutions []string, guesses []string) {
+	key := dictionaryKey{lang: lang.code, length: length}
+	triesMu.Lock()
+	if solutions == nil && guesses == nil {
+		delete(customLists, key)
+	} else {
+		customLists[key] = WordLists{solutions: solutions, guesses: guesses}
+	}
+	delete(triesCache, key)
+	triesMu.Unlock()
+
+	// everything derived from the old lists is stale
+	patternsMu.Lock()
+	delete(patternsCache, key)
+	patternsMu.Unlock()
+	openerMu.Lock()
+	for opener := range openerCache {
+		if opener.dictionary == key {
+			delete(openerCache, opener)
+		}
+	}
+	openerMu.Unlock()
+}
+
+// customChecksum identifies the word lists loaded from files for key, it
+// reports false if the embedded lists are used.
+func customChecksum(key dictionaryKey) ([sha256.Size]byte, bool) {
+	triesMu.Lock()
+	custom, ok := customLists[key]
+	triesMu.Unlock()
+	if !ok {
+		return [sha256.Size]byte{}, false
+	}
+	return patternChecksum(custom.guesses, custom.solutions), true
+}
+
+// WordListError lists the bad entries of a word list file by line.
+type WordListError struct {
+	path    string
+	entries []string
+	more    int
+}
+
+func (e *WordListError) Error() string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("Error: Invalid words in %s", e.path))
+	for _, entry := range e.entries {
+		s.WriteString("\n  " + entry)
+	}
+	if e.more > 0 {
+		s.WriteString(fmt.Sprintf("\n  and %d more", e.more))
+	}
+	return s.String()
+}
+
+func (e *WordListError) add(line int, message string) {
+	if len(e.entries) == WORD_LIST_ERRORS {
+		e.more++
+		return
+	}
+	e.entries = append(e.entries, fmt.Sprintf("line %d: %s", line, message))
+}
+
+// loadWordList reads the words of a file for games of lang with words of
+// length. CSV files, ending in .csv, use the first column and may start with
+// a "word" header like the embedded lists. Other files hold a word per line,
+// blank lines and lines starting with # are skipped. Every word has to have
+// the right length and only use letters of the alphabet, otherwise all bad
+// entries are reported with their line.
+func loadWordList(path string, lang *Language, length int) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []wordListEntry
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		entries, err = readCSVWords(data)
+	} else {
+		entries, err = readTextWords(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error: Could not read %s: %w", path, err)
+	}
+
+	words := make([]string, 0, len(entries))
+	seen := make(map[string]bool, len(entries))
+	bad := &WordListError{path: path}
+	for _, entry := range entries {
+		word := strings.ToLower(entry.word)
+		if message := checkWord(word, lang, length); message != "" {
+			bad.add(entry.line, message)
+			continue
+		}
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	if len(bad.entries) > 0 {
+		return nil, bad
+	}
+	if len(words) == 0 {
+		return nil, fmt.Errorf("Error: No words of length %d in %s", length, path)
+	}
+	return words, nil
+}
+
+// checkWord returns why word can't be played in lang with words of length,
+// or an empty string if it can.
+func checkWord(word string, lang *Language, length int) string {
+	if utf8.RuneCountInString(word) != length {
+		return fmt.Sprintf("'%s' has to be %d letters long", word, length)
+	}
+	if !lang.valid(word) {
+		return fmt.Sprintf("'%s' has characters outside of the %s alphabet", word, lang.name)
+	}
+	return ""
+}
+
+type wordListEntry struct {
+	line int
+	word string
+}
+
+func readCSVWords(data []byte) ([]wordListEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	entries := make([]wordListEntry, 0)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		word := strings.TrimSpace(record[0])
+		if word == "" || (first && word == "word") {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		entries = append(entries, wordListEntry{line: line, word: word})
+	}
+}
+
+func readTextWords(data []byte) ([]wordListEntry, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	entries := make([]wordListEntry, 0)
+	for line := 1; scanner.Scan(); line++ {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+		entries = append(entries, wordListEntry{line: line, word: word})
+	}
+	return entries, scanner.Err()
+}
+
+// loadWordLists reads the solution and guess files for words of lang and
+// length and uses them for new games. Lists without a file keep the embedded
+// words.
+func loadWordLists(lang *Language, length int, solutionsPath string, guessesPath string) error {
+	if solutionsPath == "" && guessesPath == "" {
+		return nil
+	}
+	var solutions, guesses []string
+	var err error
+	if solutionsPath != "" {
+		if solutions, err = loadWordList(solutionsPath, lang, length); err != nil {
+			return err
+		}
+	}
+	if guessesPath != "" {
+		if guesses, err = loadWordList(guessesPath, lang, length); err != nil {
+			return err
+		}
+	}
+	useWordLists(lang, length, solutions, guesses)
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeWordList(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadWordList(t *testing.T) {
+	text := writeWordList(t, "jargon.txt", "# team words\nSPRNT\n\n  stand \nsprnt\n")
+	words, err := loadWordList(text, LANGUAGES[DEFAULT_LANGUAGE], 5)
+	if err != nil {
+		t.Fatalf("Expected the list to be loaded but got %s", err)
+	}
+	if !reflect.DeepEqual(words, []string{"sprnt", "stand"}) {
+		t.Errorf("Expected lower case words without duplicates but got %v", words)
+	}
+
+	csv := writeWordList(t, "jargon.csv", "word,meaning\nsprnt,\"a sprint, shortened\"\nstand,daily meeting\n")
+	if words, err = loadWordList(csv, LANGUAGES[DEFAULT_LANGUAGE], 5); err != nil || !reflect.DeepEqual(words, []string{"sprnt", "stand"}) {
+		t.Errorf("Expected the first column of the CSV but got %v (%v)", words, err)
+	}
+}
+
+func TestLoadWordListErrors(t *testing.T) {
+	path := writeWordList(t, "bad.txt", "sprnt\nsynergy\nkpis!\n\nstand\nmvp\n")
+	_, err := loadWordList(path, LANGUAGES[DEFAULT_LANGUAGE], 5)
+	var listErr *WordListError
+	if !errors.As(err, &listErr) {
+		t.Fatalf("Expected the bad entries to be reported but got %v", err)
+	}
+	expected := []string{
+		"line 2: 'synergy' has to be 5 letters long",
+		"line 3: 'kpis!' has characters outside of the English alphabet",
+		"line 6: 'mvp' has to be 5 letters long",
+	}
+	if !reflect.DeepEqual(listErr.entries, expected) {
+		t.Errorf("Expected %v but got %v", expected, listErr.entries)
+	}
+
+	csv := writeWordList(t, "bad.csv", "word\nsprnt\nsprints\n")
+	if _, err := loadWordList(csv, LANGUAGES[DEFAULT_LANGUAGE], 5); err == nil || !strings.Contains(err.Error(), "line 3: 'sprints'") {
+		t.Errorf("Expected the line of the CSV entry but got %v", err)
+	}
+
+	many := writeWordList(t, "many.txt", strings.Repeat("abc\n", WORD_LIST_ERRORS+3))
+	if _, err := loadWordList(many, LANGUAGES[DEFAULT_LANGUAGE], 5); err == nil || !strings.HasSuffix(err.Error(), "and 3 more") {
+		t.Errorf("Expected the number of unlisted entries but got %v", err)
+	}
+
+	empty := writeWordList(t, "empty.txt", "# nothing yet\n")
+	if _, err := loadWordList(empty, LANGUAGES[DEFAULT_LANGUAGE], 5); err == nil {
+		t.Errorf("Expected an error for a list without words")
+	}
+	if _, err := loadWordList(filepath.Join(t.TempDir(), "missing.txt"), LANGUAGES[DEFAULT_LANGUAGE], 5); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected an error for a missing file but got %v", err)
+	}
+}
+
+func TestLoadWordLists(t *testing.T) {
+	t.Cleanup(func() { useWordLists(LANGUAGES[DEFAULT_LANGUAGE], 5, nil, nil) })
+	solutions := writeWordList(t, "jargon.txt", "sprnt\nstand\n")
+	if err := loadWordLists(LANGUAGES[DEFAULT_LANGUAGE], 5, solutions, ""); err != nil {
+		t.Fatalf("Expected the lists to be loaded but got %s", err)
+	}
+
+	game, err := NewGame(DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := game.boards[0]
+	if words := board.trie.words(); !reflect.DeepEqual(words, []string{"sprnt", "stand"}) {
+		t.Errorf("Expected the solutions of the file but got %d words", len(words))
+	}
+	for _, word := range []string{"sprnt", "crane", "aahed"} {
+		if !board.guessTrie.findWord(word) {
+			t.Errorf("Expected '%s' to be a valid guess", word)
+		}
+	}
+
+	guesses := writeWordList(t, "guesses.csv", "word\nscrum\n")
+	if err := loadWordLists(LANGUAGES[DEFAULT_LANGUAGE], 5, solutions, guesses); err != nil {
+		t.Fatal(err)
+	}
+	_, guessTrie, _ := loadTries(LANGUAGES[DEFAULT_LANGUAGE], 5)
+	if !guessTrie.findWord("scrum") || !guessTrie.findWord("stand") || guessTrie.findWord("crane") {
+		t.Errorf("Expected only the listed guesses and the solutions to be valid")
+	}
+
+	useWordLists(LANGUAGES[DEFAULT_LANGUAGE], 5, nil, nil)
+	if trie, _, _ := loadTries(LANGUAGES[DEFAULT_LANGUAGE], 5); !trie.findWord("earth") || trie.findWord("sprnt") {
+		t.Errorf("Expected the embedded lists to be restored")
+	}
+}